@@ -0,0 +1,84 @@
+package lib
+
+import (
+	"net/http"
+)
+
+type bufferedResponseWriter struct {
+	ctx *Context
+}
+
+/*
+Header Method
+*/
+func (bw *bufferedResponseWriter) Header() http.Header {
+	return bw.ctx.Writer.Headers
+}
+
+/*
+WriteHeader Method
+*/
+func (bw *bufferedResponseWriter) WriteHeader(code int) {
+	bw.ctx.Writer.Status(code)
+}
+
+/*
+Write Method
+*/
+func (bw *bufferedResponseWriter) Write(data []byte) (int, error) {
+	return bw.ctx.Writer.Write(data)
+}
+
+/*
+Flush Method
+*/
+func (bw *bufferedResponseWriter) Flush() {
+	bw.ctx.Flush()
+}
+
+/*
+FromHTTPMiddleware Function
+*/
+func FromHTTPMiddleware(mw func(http.Handler) http.Handler) Middleware {
+	return func(ctx *Context, next PipelineCallback) {
+		buffered := ctx.BufferedWriter()
+		handler := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx.Request = r
+			next()
+			if w != buffered {
+				ctx.replayTo(w)
+			}
+		}))
+		handler.ServeHTTP(buffered, ctx.Request)
+	}
+}
+
+/*
+ToHTTPMiddleware Function
+*/
+func ToHTTPMiddleware(mws ...Middleware) func(http.Handler) http.Handler {
+	return PlainRouter().HTTPMiddleware(mws...)
+}
+
+/*
+HTTPMiddleware Method
+*/
+func (rg *Router) HTTPMiddleware(mws ...Middleware) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := NewContext(w, r)
+			ctx.SetResponder(rg)
+			ctx.SetProxies(rg.TrustedProxies())
+			pipeline := NewPipeline(ctx)
+			pipeline.Add(mws...)
+			pipeline.Add(func(ctx *Context, next PipelineCallback) {
+				h.ServeHTTP(ctx.BufferedWriter(), ctx.Request)
+				next()
+			})
+			pipeline.Start(func() {
+				ctx.Start()
+			}, nil)
+			ctx.Finalize()
+		})
+	}
+}
@@ -0,0 +1,341 @@
+package lib
+
+import (
+	"container/list"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+CacheConfig Object
+*/
+type CacheConfig struct {
+	Name      string
+	TTL       time.Duration
+	QueryKeys []string
+}
+
+type cacheEntry struct {
+	key     string
+	base    string
+	route   string
+	status  int
+	headers http.Header
+	body    []byte
+	stored  time.Time
+	expires time.Time
+	size    int64
+	shared  bool
+}
+
+/*
+ResponseCache Object
+*/
+type ResponseCache struct {
+	mu         sync.Mutex
+	maxBytes   int64
+	maxEntries int
+	ttl        time.Duration
+	size       int64
+	entries    map[string]*list.Element
+	order      *list.List
+	varies     map[string][]string
+}
+
+/*
+Len Method
+*/
+func (rc *ResponseCache) Len() int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return rc.order.Len()
+}
+
+/*
+Size Method
+*/
+func (rc *ResponseCache) Size() int64 {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return rc.size
+}
+
+/*
+Invalidate Method
+*/
+func (rc *ResponseCache) Invalidate(routeName string) int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	removed := 0
+	for el := rc.order.Front(); el != nil; {
+		next := el.Next()
+		if entry := el.Value.(*cacheEntry); entry.route == routeName {
+			delete(rc.varies, entry.base)
+			rc.removeElement(el)
+			removed++
+		}
+		el = next
+	}
+	return removed
+}
+
+/*
+Purge Method
+*/
+func (rc *ResponseCache) Purge() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.entries = map[string]*list.Element{}
+	rc.varies = map[string][]string{}
+	rc.order.Init()
+	rc.size = 0
+}
+
+/*
+Middleware Method
+*/
+func (rc *ResponseCache) Middleware(config CacheConfig) Middleware {
+	return func(ctx *Context, next PipelineCallback) {
+		if ctx.Method != http.MethodGet && ctx.Method != http.MethodHead {
+			next()
+			return
+		}
+		requestCC := ParseCacheControl(ctx.Request.Header.Get("Cache-Control"))
+		if _, found := requestCC["no-store"]; found {
+			next()
+			return
+		}
+		route := config.routeName(ctx)
+		base := rc.baseKey(ctx, route, config)
+		authorized := len(ctx.Request.Header.Get("Authorization")) > 0
+		_, noCache := requestCC["no-cache"]
+		if !noCache {
+			if entry := rc.get(base, ctx, requestCC); entry != nil && (entry.shared || !authorized) {
+				w := ctx.Writer
+				for key, vals := range entry.headers {
+					w.Headers[key] = append([]string(nil), vals...)
+				}
+				w.Header("Age", strconv.Itoa(int(time.Since(entry.stored)/time.Second)))
+				w.Header("X-Cache", "HIT")
+				w.Status(entry.status)
+				w.ClearBuffer()
+				w.Write(entry.body)
+				return
+			}
+		}
+		if _, found := requestCC["only-if-cached"]; found {
+			ctx.Error(http.StatusGatewayTimeout, "Response not cached")
+			return
+		}
+		next()
+
+		w := ctx.Writer
+		if ctx.Method != http.MethodGet || ctx.finalized || w.Streaming() || w.status() != http.StatusOK {
+			return
+		}
+		if len(w.GetHeader("Set-Cookie")) > 0 || HeaderHasToken(w.Headers, "Vary", "*") {
+			return
+		}
+		responseCC := ParseCacheControl(w.GetHeader("Cache-Control"))
+		for _, directive := range []string{"no-store", "private", "no-cache"} {
+			if _, found := responseCC[directive]; found {
+				return
+			}
+		}
+		_, public := responseCC["public"]
+		_, sMaxAge := responseCC["s-maxage"]
+		if authorized && !public && !sMaxAge {
+			return
+		}
+		ttl := config.TTL
+		if ttl <= 0 {
+			ttl = rc.ttl
+		}
+		for _, directive := range []string{"max-age", "s-maxage"} {
+			if seconds, err := strconv.Atoi(responseCC[directive]); err == nil {
+				ttl = time.Duration(seconds) * time.Second
+			}
+		}
+		if ttl <= 0 {
+			return
+		}
+		w.Header("X-Cache", "MISS")
+		headers := w.Headers.Clone()
+		headers.Del("X-Cache")
+		rc.set(base, route, ctx, &cacheEntry{
+			status:  w.status(),
+			headers: headers,
+			body:    append([]byte(nil), w.Bytes()...),
+			stored:  time.Now(),
+			expires: time.Now().Add(ttl),
+			shared:  public || sMaxAge,
+		})
+	}
+}
+
+func (config *CacheConfig) routeName(ctx *Context) string {
+	if len(config.Name) > 0 {
+		return config.Name
+	}
+	if ctx.Route == nil {
+		return ctx.Path
+	}
+	if name := ctx.Route.GetName(); len(name) > 0 {
+		return name
+	}
+	return ctx.Route.path
+}
+
+func (rc *ResponseCache) baseKey(ctx *Context, route string, config CacheConfig) string {
+	parts := []string{ctx.Host, route}
+	params := ctx.Params.StringMap()
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		parts = append(parts, key+"="+params[key])
+	}
+	for _, key := range config.QueryKeys {
+		parts = append(parts, "?"+key+"="+ctx.QueryValue(key))
+	}
+	return strings.Join(parts, "\x00")
+}
+
+func varyKey(base string, names []string, r *http.Request) string {
+	key := base
+	for _, name := range names {
+		key += "\x00" + name + ":" + strings.Join(r.Header.Values(name), ",")
+	}
+	return key
+}
+
+func (rc *ResponseCache) get(base string, ctx *Context, requestCC StringMap) *cacheEntry {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	el, found := rc.entries[varyKey(base, rc.varies[base], ctx.Request)]
+	if !found {
+		return nil
+	}
+	entry := el.Value.(*cacheEntry)
+	now := time.Now()
+	if stale := now.Sub(entry.expires); stale > 0 {
+		maxStale, allowed := requestCC["max-stale"]
+		if !allowed {
+			rc.removeElement(el)
+			return nil
+		}
+		if limit, ok := cacheDirectiveSeconds(maxStale); len(maxStale) > 0 && (!ok || stale > limit) {
+			return nil
+		}
+	}
+	if limit, ok := cacheDirectiveSeconds(requestCC["max-age"]); ok && now.Sub(entry.stored) > limit {
+		return nil
+	}
+	if limit, ok := cacheDirectiveSeconds(requestCC["min-fresh"]); ok && entry.expires.Sub(now) < limit {
+		return nil
+	}
+	rc.order.MoveToFront(el)
+	return entry
+}
+
+func cacheDirectiveSeconds(val string) (time.Duration, bool) {
+	seconds, err := strconv.Atoi(val)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+func (rc *ResponseCache) set(base string, route string, ctx *Context, entry *cacheEntry) {
+	names := []string{}
+	for _, val := range entry.headers.Values("Vary") {
+		for _, name := range strings.Split(val, ",") {
+			if name = http.CanonicalHeaderKey(strings.TrimSpace(name)); len(name) > 0 {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	entry.key = varyKey(base, names, ctx.Request)
+	entry.base = base
+	entry.route = route
+	entry.size = int64(len(entry.body) + len(entry.key))
+	for key, vals := range entry.headers {
+		entry.size += int64(len(key))
+		for _, val := range vals {
+			entry.size += int64(len(val))
+		}
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rc.maxBytes > 0 && entry.size > rc.maxBytes {
+		return
+	}
+	if el, found := rc.entries[entry.key]; found {
+		rc.removeElement(el)
+	}
+	if previous, found := rc.varies[base]; found && strings.Join(previous, ",") != strings.Join(names, ",") {
+		for el := rc.order.Front(); el != nil; {
+			next := el.Next()
+			if el.Value.(*cacheEntry).base == base {
+				rc.removeElement(el)
+			}
+			el = next
+		}
+	}
+	rc.varies[base] = names
+	rc.entries[entry.key] = rc.order.PushFront(entry)
+	rc.size += entry.size
+	for rc.order.Len() > 0 && ((rc.maxBytes > 0 && rc.size > rc.maxBytes) || (rc.maxEntries > 0 && rc.order.Len() > rc.maxEntries)) {
+		rc.removeElement(rc.order.Back())
+	}
+}
+
+func (rc *ResponseCache) removeElement(el *list.Element) {
+	entry := el.Value.(*cacheEntry)
+	rc.order.Remove(el)
+	delete(rc.entries, entry.key)
+	rc.size -= entry.size
+}
+
+/*
+ParseCacheControl Function
+*/
+func ParseCacheControl(header string) StringMap {
+	directives := StringMap{}
+	for _, part := range strings.Split(header, ",") {
+		part = strings.TrimSpace(part)
+		if len(part) < 1 {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		key := strings.ToLower(strings.TrimSpace(kv[0]))
+		val := ""
+		if len(kv) == 2 {
+			val = strings.Trim(strings.TrimSpace(kv[1]), "\"")
+		}
+		directives[key] = val
+	}
+	return directives
+}
+
+/*
+NewResponseCache Function
+*/
+func NewResponseCache(maxBytes int64, maxEntries int, ttl time.Duration) *ResponseCache {
+	return &ResponseCache{
+		maxBytes:   maxBytes,
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		entries:    map[string]*list.Element{},
+		order:      list.New(),
+		varies:     map[string][]string{},
+	}
+}
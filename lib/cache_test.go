@@ -0,0 +1,201 @@
+package lib
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func (rc *ResponseCache) age(d time.Duration) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	for el := rc.order.Front(); el != nil; el = el.Next() {
+		entry := el.Value.(*cacheEntry)
+		entry.stored = entry.stored.Add(-d)
+		entry.expires = entry.expires.Add(-d)
+	}
+}
+
+type cacheTestServer struct {
+	rt           *Router
+	calls        int
+	cacheControl string
+	vary         string
+	body         string
+}
+
+func newCacheTestServer(rc *ResponseCache) *cacheTestServer {
+	srv := &cacheTestServer{cacheControl: "max-age=60", body: "cached body"}
+	srv.rt = PlainRouter()
+	srv.rt.AddMiddleware(rc.Middleware(CacheConfig{}))
+	srv.rt.GET("/item/:id", func(ctx *Context) {
+		srv.calls++
+		ctx.Writer.Header("Cache-Control", srv.cacheControl)
+		if len(srv.vary) > 0 {
+			ctx.Writer.Header("Vary", srv.vary)
+		}
+		ctx.WriteString(srv.body)
+	})
+	return srv
+}
+
+func (srv *cacheTestServer) get(path string, headers map[string]string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodGet, path, nil)
+	for key, val := range headers {
+		if key == "Host" {
+			r.Host = val
+			continue
+		}
+		r.Header.Set(key, val)
+	}
+	rec := httptest.NewRecorder()
+	srv.rt.ServeHTTP(rec, r)
+	return rec
+}
+
+func TestCacheRequestDirectives(t *testing.T) {
+	cases := []struct {
+		name      string
+		age       time.Duration
+		requestCC string
+		hit       bool
+	}{
+		{"fresh", 0, "", true},
+		{"expired", 70 * time.Second, "", false},
+		{"max-age within", 10 * time.Second, "max-age=30", true},
+		{"max-age exceeded", 40 * time.Second, "max-age=30", false},
+		{"max-stale any", 70 * time.Second, "max-stale", true},
+		{"max-stale within", 70 * time.Second, "max-stale=20", true},
+		{"max-stale exceeded", 70 * time.Second, "max-stale=5", false},
+		{"min-fresh within", 10 * time.Second, "min-fresh=30", true},
+		{"min-fresh exceeded", 40 * time.Second, "min-fresh=30", false},
+		{"no-cache", 0, "no-cache", false},
+		{"only-if-cached", 0, "only-if-cached", true},
+	}
+	for _, tc := range cases {
+		rc := NewResponseCache(0, 0, time.Minute)
+		srv := newCacheTestServer(rc)
+		srv.get("/item/1", nil)
+		rc.age(tc.age)
+		rec := srv.get("/item/1", map[string]string{"Cache-Control": tc.requestCC})
+		hit := rec.Header().Get("X-Cache") == "HIT"
+		if hit != tc.hit || (srv.calls == 1) != tc.hit {
+			t.Errorf("%s: expected hit=%v, got X-Cache %q after %d calls", tc.name, tc.hit, rec.Header().Get("X-Cache"), srv.calls)
+		}
+		if rec.Code != http.StatusOK || rec.Body.String() != srv.body {
+			t.Errorf("%s: unexpected response %d %q", tc.name, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestCacheOnlyIfCachedMiss(t *testing.T) {
+	srv := newCacheTestServer(NewResponseCache(0, 0, time.Minute))
+	rec := srv.get("/item/1", map[string]string{"Cache-Control": "only-if-cached"})
+	if rec.Code != http.StatusGatewayTimeout || srv.calls != 0 {
+		t.Errorf("expected 504 without calling handler, got %d after %d calls", rec.Code, srv.calls)
+	}
+}
+
+func TestCacheVaryReplacement(t *testing.T) {
+	rc := NewResponseCache(0, 0, time.Minute)
+	srv := newCacheTestServer(rc)
+	srv.vary = "Accept-Language"
+	srv.get("/item/1", map[string]string{"Accept-Language": "en"})
+	srv.get("/item/1", map[string]string{"Accept-Language": "fr"})
+	if rc.Len() != 2 {
+		t.Fatalf("expected one entry per language, got %d", rc.Len())
+	}
+	if rec := srv.get("/item/1", map[string]string{"Accept-Language": "fr"}); rec.Header().Get("X-Cache") != "HIT" {
+		t.Error("expected hit for cached language")
+	}
+
+	srv.vary = "Accept"
+	srv.get("/item/1", map[string]string{"Accept": "text/plain", "Cache-Control": "no-cache"})
+	if rc.Len() != 1 {
+		t.Errorf("expected variants under the old Vary to be dropped, got %d entries", rc.Len())
+	}
+	if rec := srv.get("/item/1", map[string]string{"Accept": "text/html"}); rec.Header().Get("X-Cache") == "HIT" {
+		t.Error("expected miss for a different Accept value")
+	}
+}
+
+func TestCacheByteLimitEviction(t *testing.T) {
+	rc := NewResponseCache(1000, 0, time.Minute)
+	srv := newCacheTestServer(rc)
+	srv.body = strings.Repeat("x", 400)
+	srv.get("/item/1", nil)
+	srv.get("/item/2", nil)
+	srv.get("/item/1", nil)
+	srv.get("/item/3", nil)
+	if rc.Len() != 2 || rc.Size() > 1000 {
+		t.Fatalf("expected two entries within 1000 bytes, got %d entries of %d bytes", rc.Len(), rc.Size())
+	}
+	if rec := srv.get("/item/2", map[string]string{"Cache-Control": "only-if-cached"}); rec.Code != http.StatusGatewayTimeout {
+		t.Error("expected least recently used entry to be evicted")
+	}
+	if rec := srv.get("/item/1", nil); rec.Header().Get("X-Cache") != "HIT" {
+		t.Error("expected recently used entry to survive")
+	}
+
+	srv.body = strings.Repeat("x", 2000)
+	srv.get("/item/4", nil)
+	if rc.Len() != 2 {
+		t.Errorf("expected oversized response not to be stored, got %d entries", rc.Len())
+	}
+}
+
+func TestCacheAuthorization(t *testing.T) {
+	auth := map[string]string{"Authorization": "Bearer token"}
+	cases := []struct {
+		name         string
+		cacheControl string
+		stored       bool
+	}{
+		{"private by default", "max-age=60", false},
+		{"public", "public, max-age=60", true},
+		{"s-maxage", "s-maxage=60", true},
+	}
+	for _, tc := range cases {
+		rc := NewResponseCache(0, 0, time.Minute)
+		srv := newCacheTestServer(rc)
+		srv.cacheControl = tc.cacheControl
+		srv.get("/item/1", auth)
+		if (rc.Len() == 1) != tc.stored {
+			t.Errorf("%s: expected stored=%v, got %d entries", tc.name, tc.stored, rc.Len())
+		}
+	}
+
+	srv := newCacheTestServer(NewResponseCache(0, 0, time.Minute))
+	srv.get("/item/1", nil)
+	if rec := srv.get("/item/1", auth); rec.Header().Get("X-Cache") == "HIT" {
+		t.Error("expected non-public entry not to be served to an authorized request")
+	}
+}
+
+func TestCacheKeyIncludesHost(t *testing.T) {
+	rc := NewResponseCache(0, 0, time.Minute)
+	srv := newCacheTestServer(rc)
+	srv.get("/item/1", map[string]string{"Host": "a.example.com"})
+	if rec := srv.get("/item/1", map[string]string{"Host": "b.example.com"}); rec.Header().Get("X-Cache") == "HIT" {
+		t.Error("expected separate entries per host")
+	}
+	if rc.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", rc.Len())
+	}
+}
+
+func TestCacheInvalidate(t *testing.T) {
+	rc := NewResponseCache(0, 0, time.Minute)
+	srv := newCacheTestServer(rc)
+	srv.vary = "Accept-Language"
+	srv.get("/item/1", nil)
+	srv.get("/item/2", nil)
+	if removed := rc.Invalidate("/item/:id"); removed != 2 {
+		t.Errorf("expected 2 entries removed, got %d", removed)
+	}
+	if rc.Len() != 0 || rc.Size() != 0 || len(rc.varies) != 0 {
+		t.Errorf("expected empty cache, got %d entries, %d bytes, %d vary sets", rc.Len(), rc.Size(), len(rc.varies))
+	}
+}
@@ -0,0 +1,186 @@
+package lib
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+/*
+DefaultCompressibleTypes Variable
+*/
+var DefaultCompressibleTypes = []string{
+	"text/*",
+	"application/json",
+	"application/*+json",
+	"application/javascript",
+	"application/xml",
+	"application/*+xml",
+	"image/svg+xml",
+}
+
+/*
+CompressionConfig Object
+*/
+type CompressionConfig struct {
+	Level        *int
+	MinSize      *int
+	ContentTypes []string
+}
+
+var defaultCompression = MakeCompressionMiddleware(CompressionConfig{})
+
+/*
+CompressionMiddleware Function
+*/
+func CompressionMiddleware(ctx *Context, next PipelineCallback) {
+	defaultCompression(ctx, next)
+}
+
+/*
+MakeCompressionMiddleware Function
+*/
+func MakeCompressionMiddleware(config CompressionConfig) Middleware {
+	level, minSize := gzip.DefaultCompression, 1024
+	if config.Level != nil {
+		level = *config.Level
+	}
+	if config.MinSize != nil {
+		minSize = *config.MinSize
+	}
+	if len(config.ContentTypes) < 1 {
+		config.ContentTypes = DefaultCompressibleTypes
+	}
+	return func(ctx *Context, next PipelineCallback) {
+		if len(ctx.Request.Header.Get("Range")) > 0 {
+			next()
+			return
+		}
+		encoding := NegotiateEncoding(ctx.Request.Header.Get("Accept-Encoding"), "gzip", "deflate")
+		ctx.Writer.AddStreamFilter(func(w *Writer, dst io.Writer) io.Writer {
+			if !config.compressible(w, w.GetHeader("Content-Type")) {
+				return dst
+			}
+			AddVary(w.Headers, "Accept-Encoding")
+			if length, err := strconv.Atoi(w.GetHeader("Content-Length")); err == nil && length < minSize {
+				return dst
+			}
+			if len(encoding) < 1 {
+				return dst
+			}
+			encoder, err := compressionEncoder(encoding, level, dst)
+			if err != nil {
+				return dst
+			}
+			markEncoded(w, encoding)
+			return encoder
+		})
+		next()
+
+		w := ctx.Writer
+		if ctx.finalized || w.Streaming() {
+			return
+		}
+		body := w.Bytes()
+		contentType := w.GetHeader("Content-Type")
+		if len(contentType) < 1 && len(body) > 0 {
+			contentType = http.DetectContentType(body)
+		}
+		if !config.compressible(w, contentType) {
+			return
+		}
+		AddVary(w.Headers, "Accept-Encoding")
+		if len(encoding) < 1 || len(body) < minSize {
+			return
+		}
+		var compressed bytes.Buffer
+		encoder, err := compressionEncoder(encoding, level, &compressed)
+		if err != nil {
+			return
+		}
+		encoder.Write(body)
+		encoder.Close()
+		if len(w.GetHeader("Content-Type")) < 1 {
+			w.Header("Content-Type", contentType)
+		}
+		w.ClearBuffer()
+		w.Write(compressed.Bytes())
+		markEncoded(w, encoding)
+	}
+}
+
+func (config *CompressionConfig) compressible(w *Writer, contentType string) bool {
+	if len(w.GetHeader("Content-Encoding")) > 0 || !bodyAllowed(w.status()) || w.status() == http.StatusPartialContent {
+		return false
+	}
+	if HeaderHasToken(w.Headers, "Cache-Control", "no-transform") {
+		return false
+	}
+	mediaType, _ := ParseMediaType(contentType)
+	if len(mediaType) < 1 {
+		return false
+	}
+	for _, pattern := range config.ContentTypes {
+		if MatchMediaType(pattern, mediaType) {
+			return true
+		}
+	}
+	return false
+}
+
+func compressionEncoder(encoding string, level int, dst io.Writer) (io.WriteCloser, error) {
+	if encoding == "deflate" {
+		return zlib.NewWriterLevel(dst, level)
+	}
+	return gzip.NewWriterLevel(dst, level)
+}
+
+func markEncoded(w *Writer, encoding string) {
+	w.Header("Content-Encoding", encoding)
+	w.DelHeader("Content-Length")
+	if etag := w.GetHeader("ETag"); len(etag) > 0 && !strings.HasPrefix(etag, "W/") {
+		w.Header("ETag", "W/"+etag)
+	}
+}
+
+/*
+NegotiateEncoding Function
+*/
+func NegotiateEncoding(header string, offers ...string) string {
+	best, bestQuality := "", 0.0
+	items := ParseQualityList(header)
+	for _, offer := range offers {
+		quality := -1.0
+		for _, item := range items {
+			if item.Value == offer {
+				quality = item.Quality
+				break
+			}
+		}
+		if quality < 0 {
+			for _, item := range items {
+				if item.Value == "*" {
+					quality = item.Quality
+					break
+				}
+			}
+		}
+		if quality > bestQuality {
+			best, bestQuality = offer, quality
+		}
+	}
+	return best
+}
+
+/*
+AddVary Function
+*/
+func AddVary(headers http.Header, key string) {
+	if !HeaderHasToken(headers, "Vary", key) && !HeaderHasToken(headers, "Vary", "*") {
+		headers.Add("Vary", key)
+	}
+}
@@ -0,0 +1,116 @@
+package lib
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	cases := []struct {
+		header string
+		want   string
+	}{
+		{"", ""},
+		{"gzip", "gzip"},
+		{"deflate, gzip", "gzip"},
+		{"gzip;q=0.5, deflate", "deflate"},
+		{"gzip;q=0, deflate;q=0", ""},
+		{"*", "gzip"},
+		{"*;q=0.3, deflate", "deflate"},
+		{"gzip;q=0, *", "deflate"},
+		{"br, identity", ""},
+	}
+	for _, tc := range cases {
+		if got := NegotiateEncoding(tc.header, "gzip", "deflate"); got != tc.want {
+			t.Errorf("%q: got %q, want %q", tc.header, got, tc.want)
+		}
+	}
+}
+
+func compressionRouter(mw Middleware, handler func(*Context)) *Router {
+	rt := PlainRouter()
+	rt.AddMiddleware(mw)
+	rt.GET("/", handler)
+	return rt
+}
+
+func compressionRequest(rt *Router) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	rt.ServeHTTP(rec, r)
+	return rec
+}
+
+func gunzip(t *testing.T, body io.Reader) string {
+	t.Helper()
+	reader, err := gzip.NewReader(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestCompressionBuffered(t *testing.T) {
+	body := strings.Repeat("compress me ", 200)
+	cases := []struct {
+		name    string
+		config  CompressionConfig
+		headers map[string]string
+		encoded bool
+	}{
+		{"compressible text", CompressionConfig{}, map[string]string{"Content-Type": "text/plain"}, true},
+		{"below min size", CompressionConfig{MinSize: IntRef(1 << 20)}, map[string]string{"Content-Type": "text/plain"}, false},
+		{"zero min size", CompressionConfig{MinSize: IntRef(0)}, map[string]string{"Content-Type": "text/plain"}, true},
+		{"no-transform", CompressionConfig{}, map[string]string{"Content-Type": "text/plain", "Cache-Control": "no-transform"}, false},
+		{"incompressible type", CompressionConfig{}, map[string]string{"Content-Type": "image/png"}, false},
+	}
+	for _, tc := range cases {
+		rt := compressionRouter(MakeCompressionMiddleware(tc.config), func(ctx *Context) {
+			for key, val := range tc.headers {
+				ctx.Writer.Header(key, val)
+			}
+			ctx.WriteString(body)
+		})
+		rec := compressionRequest(rt)
+		encoded := rec.Header().Get("Content-Encoding") == "gzip"
+		if encoded != tc.encoded {
+			t.Errorf("%s: expected encoded=%v, got headers %v", tc.name, tc.encoded, rec.Header())
+			continue
+		}
+		if encoded && gunzip(t, rec.Body) != body {
+			t.Errorf("%s: body did not round-trip", tc.name)
+		}
+		if !encoded && rec.Body.String() != body {
+			t.Errorf("%s: expected identity body", tc.name)
+		}
+	}
+}
+
+func TestCompressionStreamingFilter(t *testing.T) {
+	rt := compressionRouter(CompressionMiddleware, func(ctx *Context) {
+		ctx.Writer.Header("Content-Type", "text/event-stream")
+		ctx.Stream()
+		ctx.WriteString("data: one\n\n")
+		ctx.Flush()
+		ctx.WriteString("data: two\n\n")
+	})
+	rec := compressionRequest(rt)
+	if rec.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("expected streamed gzip response, got %v", rec.Header())
+	}
+	if !HeaderHasToken(rec.Header(), "Vary", "Accept-Encoding") {
+		t.Error("expected Vary: Accept-Encoding")
+	}
+	if got := gunzip(t, rec.Body); got != "data: one\n\ndata: two\n\n" {
+		t.Errorf("unexpected stream body %q", got)
+	}
+}
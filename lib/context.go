@@ -1,9 +1,16 @@
 package lib
 
 import (
+	"context"
+	"io"
+	"mime"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 )
 
 /*
@@ -19,15 +26,30 @@ type Context struct {
 	Port       string
 	Schema     string
 	Matched    bool
+	Route      *Route
 	Errors     *Errors
 	Params     *Params
-	responder  *ContextResponder
+	responder  ContextResponder
 	IOWriter   http.ResponseWriter
 	finalized  bool
+	proxies    *TrustedProxies
+	clientIP   string
+	recorder   *ResponseRecorder
+	buffered   *bufferedResponseWriter
+	finalizers []PipelineCallback
+	values     *ObjectMap
+	released   bool
+	detached   bool
+
+	pathMatcher *PathMatcher
+	pathValues  []string
 }
 
 func (ctx *Context) init() {
 	path := ctx.Request.RequestURI
+	if len(path) < 1 && ctx.Request.URL != nil {
+		path = ctx.Request.URL.RequestURI()
+	}
 	if len(path) < 1 {
 		path = "/"
 	}
@@ -37,13 +59,51 @@ func (ctx *Context) init() {
 	}
 	ctx.RequestURI = path
 	ctx.Matched = false
+	ctx.Route = nil
 	ctx.finalized = false
-	ctx.Errors = &Errors{}
-	ctx.Params = &Params{}
+	if ctx.Errors == nil {
+		ctx.Errors = &Errors{}
+	} else {
+		*ctx.Errors = (*ctx.Errors)[:0]
+	}
+	if ctx.Params == nil {
+		ctx.Params = &Params{}
+	} else {
+		*ctx.Params = (*ctx.Params)[:0]
+	}
+	if ctx.values == nil {
+		ctx.values = &ObjectMap{}
+	} else {
+		ctx.values.Clear()
+	}
 	ctx.Method = ctx.Request.Method
+	ctx.clientIP = ""
+	ctx.pathMatcher = nil
+	ctx.pathValues = nil
 	ctx.extractHostInfo()
 }
 
+func (ctx *Context) reset(w http.ResponseWriter, r *http.Request) {
+	if ctx.Writer == nil {
+		ctx.Writer = NewWriter()
+	} else {
+		ctx.Writer.Reset()
+	}
+	if ctx.recorder == nil {
+		ctx.recorder = NewResponseRecorder(w)
+	} else {
+		ctx.recorder.reset(w)
+	}
+	ctx.IOWriter = ctx.recorder.ResponseWriter()
+	ctx.Request = r
+	ctx.responder = nil
+	ctx.proxies = nil
+	ctx.finalizers = ctx.finalizers[:0]
+	ctx.released = false
+	ctx.detached = false
+	ctx.init()
+}
+
 func (ctx *Context) extractHostInfo() {
 	ctx.Schema = "http"
 	sch := ctx.Request.URL.Scheme
@@ -67,11 +127,179 @@ func (ctx *Context) extractHostInfo() {
 	ctx.Port = port
 }
 
+/*
+Context Method
+*/
+func (ctx *Context) Context() context.Context {
+	return ctx.Request.Context()
+}
+
+/*
+WithContext Method
+*/
+func (ctx *Context) WithContext(c context.Context) *Context {
+	ctx.Request = ctx.Request.WithContext(c)
+	return ctx
+}
+
+/*
+Done Method
+*/
+func (ctx *Context) Done() <-chan struct{} {
+	return ctx.Request.Context().Done()
+}
+
+/*
+Err Method
+*/
+func (ctx *Context) Err() error {
+	return ctx.Request.Context().Err()
+}
+
+/*
+Set Method
+*/
+func (ctx *Context) Set(key string, val interface{}) {
+	ctx.values.Set(key, val)
+}
+
+/*
+Get Method
+*/
+func (ctx *Context) Get(key string, def ...interface{}) interface{} {
+	return ctx.values.Get(key, def...)
+}
+
+/*
+GetString Method
+*/
+func (ctx *Context) GetString(key string) string {
+	return ctx.values.GetString(key)
+}
+
+/*
+GetInt Method
+*/
+func (ctx *Context) GetInt(key string, def ...int) int {
+	return ctx.values.GetInt(key, def...)
+}
+
+/*
+GetBool Method
+*/
+func (ctx *Context) GetBool(key string) bool {
+	return ctx.values.GetBool(key)
+}
+
+/*
+Has Method
+*/
+func (ctx *Context) Has(key string) bool {
+	return ctx.values.Has(key)
+}
+
+/*
+Values Method
+*/
+func (ctx *Context) Values() *ObjectMap {
+	return ctx.values
+}
+
 /*
 SetResponder Method
 */
 func (ctx *Context) SetResponder(responder ContextResponder) {
-	ctx.responder = &responder
+	ctx.responder = responder
+}
+
+/*
+SetProxies Method
+*/
+func (ctx *Context) SetProxies(proxies *TrustedProxies) {
+	ctx.proxies = proxies
+	ctx.clientIP = ""
+}
+
+/*
+ClientIP Method
+*/
+func (ctx *Context) ClientIP() string {
+	if len(ctx.clientIP) < 1 {
+		if ctx.proxies != nil && ctx.proxies.Size() > 0 {
+			ctx.clientIP = ctx.proxies.ClientIP(ctx.Request.RemoteAddr, ctx.Request.Header["X-Forwarded-For"])
+		} else {
+			ctx.clientIP = RemoteHost(ctx.Request.RemoteAddr)
+		}
+	}
+	return ctx.clientIP
+}
+
+/*
+ResponseWriter Method
+*/
+func (ctx *Context) ResponseWriter() http.ResponseWriter {
+	return ctx.recorder.ResponseWriter()
+}
+
+/*
+BufferedWriter Method
+*/
+func (ctx *Context) BufferedWriter() http.ResponseWriter {
+	if ctx.buffered == nil {
+		ctx.buffered = &bufferedResponseWriter{ctx: ctx}
+	}
+	return ctx.buffered
+}
+
+func (ctx *Context) replayTo(w http.ResponseWriter) {
+	if ctx.finalized || ctx.Writer.Streaming() {
+		return
+	}
+	body := append([]byte(nil), ctx.Writer.Bytes()...)
+	ctx.Writer.ClearBuffer()
+	w.WriteHeader(ctx.Writer.status())
+	w.Write(body)
+}
+
+/*
+Recorder Method
+*/
+func (ctx *Context) Recorder() *ResponseRecorder {
+	return ctx.recorder
+}
+
+/*
+ResponseStatus Method
+*/
+func (ctx *Context) ResponseStatus() int {
+	if ctx.recorder.Written() {
+		return ctx.recorder.Status()
+	}
+	return ctx.Writer.status()
+}
+
+/*
+ResponseSize Method
+*/
+func (ctx *Context) ResponseSize() int64 {
+	if ctx.recorder.Written() {
+		return ctx.recorder.Size()
+	}
+	return ctx.Writer.Size()
+}
+
+/*
+StartTime Method
+*/
+func (ctx *Context) StartTime() time.Time {
+	return ctx.recorder.Started()
+}
+
+/*
+Elapsed Method
+*/
+func (ctx *Context) Elapsed() time.Duration {
+	return time.Since(ctx.recorder.Started())
 }
 
 /*
@@ -100,7 +328,28 @@ Error Method
 */
 func (ctx *Context) Error(errorCode int, errMsg string) {
 	ctx.Errors.Add(errMsg, errorCode, nil)
-	(*(ctx.responder)).HandleError(ctx, errorCode, errMsg)
+	ctx.responder.HandleError(ctx, errorCode, errMsg)
+}
+
+/*
+Fail Method
+*/
+func (ctx *Context) Fail(err error) {
+	if err == nil {
+		return
+	}
+	var he *HTTPError
+	if resolver, ok := ctx.responder.(ErrorResolver); ok {
+		he = resolver.ResolveError(err)
+	} else {
+		he = DefaultErrorMappings.Resolve(err)
+	}
+	if he == nil {
+		he = NewHTTPError(http.StatusInternalServerError).WithCause(err)
+	}
+	he = normalizeHTTPError(he)
+	ctx.Errors.AddError(Error{Code: he.Status, Message: he.Message, Object: he.Details, Cause: err})
+	ctx.responder.HandleError(ctx, he.Status, he.Message)
 }
 
 /*
@@ -108,7 +357,7 @@ DetailedError Method
 */
 func (ctx *Context) DetailedError(errorCode int, errMsg string, obj interface{}) {
 	ctx.Errors.Add(errMsg, errorCode, obj)
-	(*(ctx.responder)).HandleError(ctx, errorCode, errMsg)
+	ctx.responder.HandleError(ctx, errorCode, errMsg)
 }
 
 /*
@@ -125,6 +374,88 @@ func (ctx *Context) WriteString(str string) {
 	ctx.Writer.WriteString(str)
 }
 
+/*
+Stream Method
+*/
+func (ctx *Context) Stream() {
+	if !ctx.Writer.Streaming() {
+		ctx.Writer.Stream(ctx.IOWriter)
+	}
+}
+
+/*
+Flush Method
+*/
+func (ctx *Context) Flush() {
+	ctx.Writer.FlushStream()
+}
+
+/*
+StreamFrom Method
+*/
+func (ctx *Context) StreamFrom(reader io.Reader) (int64, error) {
+	ctx.Stream()
+	var total int64
+	chunk := make([]byte, 32*1024)
+	for {
+		n, readErr := reader.Read(chunk)
+		if n > 0 {
+			written, writeErr := ctx.Writer.Write(chunk[:n])
+			total += int64(written)
+			if writeErr != nil {
+				return total, writeErr
+			}
+			ctx.Flush()
+		}
+		if readErr == io.EOF {
+			return total, nil
+		}
+		if readErr != nil {
+			return total, readErr
+		}
+	}
+}
+
+/*
+Download Method
+*/
+func (ctx *Context) Download(filename string, reader io.Reader) (int64, error) {
+	if len(ctx.Writer.GetHeader("Content-Type")) < 1 {
+		contentType := mime.TypeByExtension(filepath.Ext(filename))
+		if len(contentType) < 1 {
+			contentType = "application/octet-stream"
+		}
+		ctx.Writer.Header("Content-Type", contentType)
+	}
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": filename})
+	if len(disposition) < 1 {
+		disposition = "attachment"
+	}
+	ctx.Writer.Header("Content-Disposition", disposition)
+	return ctx.StreamFrom(reader)
+}
+
+/*
+DownloadFile Method
+*/
+func (ctx *Context) DownloadFile(path string, filename ...string) (int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+	name := filepath.Base(path)
+	if len(filename) > 0 {
+		name = filename[0]
+	}
+	ctx.Writer.Header("Content-Length", strconv.FormatInt(info.Size(), 10))
+	return ctx.Download(name, file)
+}
+
 /*
 Start Method
 */
@@ -136,12 +467,36 @@ func (ctx *Context) Start() {
 Finalize Method
 */
 func (ctx *Context) Finalize() {
+	for len(ctx.finalizers) > 0 {
+		cb := ctx.finalizers[0]
+		ctx.finalizers = ctx.finalizers[1:]
+		cb()
+	}
 	if !ctx.finalized {
+		if !ctx.Writer.Streaming() {
+			ctx.applyRange()
+		}
+		ctx.Writer.head = ctx.Method == http.MethodHead
 		ctx.Writer.PushTo(ctx.IOWriter)
 		ctx.finalized = true
 	}
 }
 
+/*
+OnFinalize Method
+*/
+func (ctx *Context) OnFinalize(cb PipelineCallback) {
+	ctx.finalizers = append(ctx.finalizers, cb)
+}
+
+/*
+SetRoute Method
+*/
+func (ctx *Context) SetRoute(route *Route) {
+	ctx.Route = route
+	ctx.SetMatched(route != nil)
+}
+
 /*
 SetMatched Method
 */
@@ -186,7 +541,7 @@ func (ctx *Context) ReadURLValues(values url.Values, key string, fallback ...str
 NewContext Function
 */
 func NewContext(w http.ResponseWriter, r *http.Request) *Context {
-	ctx := &Context{Writer: NewWriter(), IOWriter: w, Request: r}
-	ctx.init()
+	ctx := &Context{}
+	ctx.reset(w, r)
 	return ctx
 }
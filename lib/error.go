@@ -1,5 +1,15 @@
 package lib
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+)
+
 /*
 Error Object
 */
@@ -7,6 +17,30 @@ type Error struct {
 	Code    int
 	Message string
 	Object  interface{}
+	Cause   error
+}
+
+/*
+Error Method
+*/
+func (e Error) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("[%d] %s: %v", e.Code, e.Message, e.Cause)
+	}
+	return fmt.Sprintf("[%d] %s", e.Code, e.Message)
+}
+
+/*
+Unwrap Method
+*/
+func (e Error) Unwrap() error {
+	if e.Cause != nil {
+		return e.Cause
+	}
+	if err, ok := e.Object.(error); ok {
+		return err
+	}
+	return nil
 }
 
 /*
@@ -23,6 +57,56 @@ func (es *Errors) Add(msg string, code int, obj interface{}) *Error {
 	return err
 }
 
+/*
+AddError Method
+*/
+func (es *Errors) AddError(err Error) *Error {
+	*es = append(*es, err)
+	return &(*es)[len(*es)-1]
+}
+
+/*
+Error Method
+*/
+func (es Errors) Error() string {
+	msgs := make([]string, 0, len(es))
+	for _, err := range es {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+/*
+Unwrap Method
+*/
+func (es Errors) Unwrap() []error {
+	errs := make([]error, 0, len(es))
+	for _, err := range es {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+/*
+Err Method
+*/
+func (es *Errors) Err() error {
+	if es.Empty() {
+		return nil
+	}
+	return *es
+}
+
+/*
+Last Method
+*/
+func (es *Errors) Last() *Error {
+	if es.Empty() {
+		return nil
+	}
+	return &(*es)[len(*es)-1]
+}
+
 /*
 Size Method
 */
@@ -62,11 +146,17 @@ func (ehs *ErrorHandlers) Copy(ehs2 ErrorHandlers) {
 ErrorHandlerMixin Object
 */
 type ErrorHandlerMixin struct {
-	errorHandlers *ErrorHandlers
+	errorHandlers  *ErrorHandlers
+	errorMappings  *ErrorMappings
+	errorResponder ContextResponder
+	panicHandler   PanicHandler
 }
 
 func (ehm *ErrorHandlerMixin) initErrorHandlers() {
 	ehm.errorHandlers = &ErrorHandlers{}
+	ehm.errorMappings = &ErrorMappings{}
+	ehm.errorMappings.Copy(DefaultErrorMappings)
+	ehm.errorResponder = nil
 }
 
 /*
@@ -100,4 +190,177 @@ CopyErrorHandlers Method
 */
 func (ehm *ErrorHandlerMixin) CopyErrorHandlers(ehm2 ErrorHandlerMixin) {
 	ehm.errorHandlers.Copy(*ehm2.ErrorHandlers())
+	ehm.errorMappings.Copy(*ehm2.ErrorMappings())
+	ehm.errorResponder = ehm2.errorResponder
+	ehm.panicHandler = ehm2.panicHandler
+}
+
+/*
+SetErrorResponder Method
+*/
+func (ehm *ErrorHandlerMixin) SetErrorResponder(responder ContextResponder) *ErrorHandlerMixin {
+	ehm.errorResponder = responder
+	return ehm
+}
+
+/*
+GetErrorResponder Method
+*/
+func (ehm *ErrorHandlerMixin) GetErrorResponder() ContextResponder {
+	return ehm.errorResponder
+}
+
+/*
+MapError Method
+*/
+func (ehm *ErrorHandlerMixin) MapError(target error, status int) *ErrorHandlerMixin {
+	ehm.errorMappings.Add(target, status)
+	return ehm
+}
+
+/*
+ErrorMappings Method
+*/
+func (ehm *ErrorHandlerMixin) ErrorMappings() *ErrorMappings {
+	return ehm.errorMappings
+}
+
+/*
+ResolveError Method
+*/
+func (ehm *ErrorHandlerMixin) ResolveError(err error) *HTTPError {
+	return ehm.errorMappings.Resolve(err)
+}
+
+/*
+HTTPError Object
+*/
+type HTTPError struct {
+	Status  int
+	Message string
+	Cause   error
+	Details interface{}
+}
+
+/*
+Error Method
+*/
+func (he *HTTPError) Error() string {
+	if he.Cause != nil {
+		return fmt.Sprintf("%d %s: %v", he.Status, he.Message, he.Cause)
+	}
+	return fmt.Sprintf("%d %s", he.Status, he.Message)
+}
+
+/*
+Unwrap Method
+*/
+func (he *HTTPError) Unwrap() error {
+	return he.Cause
+}
+
+/*
+WithCause Method
+*/
+func (he *HTTPError) WithCause(cause error) *HTTPError {
+	he.Cause = cause
+	return he
+}
+
+/*
+WithDetails Method
+*/
+func (he *HTTPError) WithDetails(details interface{}) *HTTPError {
+	he.Details = details
+	return he
+}
+
+/*
+NewHTTPError Function
+*/
+func NewHTTPError(status int, message ...string) *HTTPError {
+	msg := http.StatusText(status)
+	if len(message) > 0 {
+		msg = strings.Join(message, " ")
+	}
+	return &HTTPError{Status: status, Message: msg}
+}
+
+func normalizeHTTPError(he *HTTPError) *HTTPError {
+	if he.Status >= 400 && len(he.Message) > 0 {
+		return he
+	}
+	fixed := *he
+	if fixed.Status < 400 {
+		fixed.Status = http.StatusInternalServerError
+	}
+	if len(fixed.Message) < 1 {
+		fixed.Message = http.StatusText(fixed.Status)
+	}
+	return &fixed
+}
+
+/*
+ErrorMapping Object
+*/
+type ErrorMapping struct {
+	Target error
+	Status int
+}
+
+/*
+ErrorMappings Object
+*/
+type ErrorMappings []ErrorMapping
+
+/*
+DefaultErrorMappings Variable
+*/
+var DefaultErrorMappings = ErrorMappings{
+	{Target: os.ErrNotExist, Status: http.StatusNotFound},
+	{Target: os.ErrPermission, Status: http.StatusForbidden},
+	{Target: ErrRangeNotSatisfiable, Status: http.StatusRequestedRangeNotSatisfiable},
+	{Target: http.ErrHandlerTimeout, Status: http.StatusServiceUnavailable},
+	{Target: context.DeadlineExceeded, Status: http.StatusServiceUnavailable},
+}
+
+/*
+Add Method
+*/
+func (ems *ErrorMappings) Add(target error, status int) {
+	comparable := target != nil && reflect.TypeOf(target).Comparable()
+	for i, em := range *ems {
+		if comparable && reflect.TypeOf(em.Target) == reflect.TypeOf(target) && em.Target == target {
+			(*ems)[i].Status = status
+			return
+		}
+	}
+	*ems = append(*ems, ErrorMapping{Target: target, Status: status})
+}
+
+/*
+Copy Method
+*/
+func (ems *ErrorMappings) Copy(ems2 ErrorMappings) {
+	for _, em := range ems2 {
+		ems.Add(em.Target, em.Status)
+	}
+}
+
+/*
+Resolve Method
+*/
+func (ems *ErrorMappings) Resolve(err error) *HTTPError {
+	var he *HTTPError
+	if errors.As(err, &he) {
+		return normalizeHTTPError(he)
+	}
+	status := http.StatusInternalServerError
+	for i := len(*ems) - 1; i >= 0; i-- {
+		if errors.Is(err, (*ems)[i].Target) {
+			status = (*ems)[i].Status
+			break
+		}
+	}
+	return NewHTTPError(status).WithCause(err)
 }
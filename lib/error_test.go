@@ -0,0 +1,58 @@
+package lib
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type sliceError struct {
+	fields []string
+}
+
+func (e sliceError) Error() string {
+	return "invalid fields"
+}
+
+func TestFailDefaultsInvalidStatus(t *testing.T) {
+	rt := PlainRouter()
+	rt.GET("/bad", RequestErrorHandler(func(ctx *Context) error {
+		return &HTTPError{Message: "bad"}
+	}).Handler())
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/bad", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500, got %d", w.Code)
+	}
+}
+
+func TestMapUncomparableError(t *testing.T) {
+	rt := PlainRouter()
+	rt.MapError(sliceError{}, http.StatusBadRequest)
+	rt.MapError(sliceError{}, http.StatusBadRequest)
+	rt.MapError(errors.New("other"), http.StatusConflict)
+	if he := rt.ResolveError(errors.New("unmapped")); he.Status != http.StatusInternalServerError {
+		t.Errorf("expected 500, got %d", he.Status)
+	}
+}
+
+func TestErrorMiddlewareStopsPipeline(t *testing.T) {
+	rt := PlainRouter()
+	rt.AddMiddleware(ErrorMiddleware(func(ctx *Context, next PipelineCallback) error {
+		if len(ctx.Request.Header.Get("X-Key")) < 1 {
+			return NewHTTPError(http.StatusUnauthorized)
+		}
+		next()
+		return nil
+	}).Middleware())
+	called := false
+	rt.GET("/", func(ctx *Context) {
+		called = true
+	})
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusUnauthorized || called {
+		t.Errorf("expected 401 without running the handler, got %d (called %v)", w.Code, called)
+	}
+}
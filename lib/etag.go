@@ -0,0 +1,191 @@
+package lib
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+)
+
+/*
+ETagConfig Object
+*/
+type ETagConfig struct {
+	Weak     bool
+	Resource func(*Context) (string, time.Time)
+}
+
+var defaultETag = MakeETagMiddleware(ETagConfig{})
+
+/*
+ETagMiddleware Function
+*/
+func ETagMiddleware(ctx *Context, next PipelineCallback) {
+	defaultETag(ctx, next)
+}
+
+/*
+MakeETagMiddleware Function
+*/
+func MakeETagMiddleware(config ETagConfig) Middleware {
+	return func(ctx *Context, next PipelineCallback) {
+		switch ctx.Method {
+		case http.MethodPut, http.MethodPatch, http.MethodDelete:
+			if config.Resource != nil && HasPreconditions(ctx.Request) {
+				etag, modified := config.Resource(ctx)
+				if !ctx.CheckPreconditions(etag, modified) {
+					return
+				}
+			}
+			next()
+			return
+		case http.MethodGet, http.MethodHead:
+			next()
+		default:
+			next()
+			return
+		}
+
+		w := ctx.Writer
+		if ctx.finalized || w.Streaming() || w.status() != http.StatusOK {
+			return
+		}
+		etag := w.GetHeader("ETag")
+		if len(etag) < 1 {
+			etag = MakeETag(w.Bytes(), config.Weak)
+			w.Header("ETag", etag)
+		}
+		modified, _ := http.ParseTime(w.GetHeader("Last-Modified"))
+		if CheckNotModified(ctx.Request, etag, modified) {
+			w.Status(http.StatusNotModified)
+			w.ClearBuffer()
+			w.DelHeader("Content-Type")
+			w.DelHeader("Content-Length")
+		}
+	}
+}
+
+/*
+CheckPreconditions Method
+*/
+func (ctx *Context) CheckPreconditions(etag string, modified time.Time) bool {
+	status := CheckPreconditions(ctx.Request, etag, modified)
+	if status != 0 {
+		ctx.Error(status, http.StatusText(status))
+		return false
+	}
+	return true
+}
+
+/*
+MakeETag Function
+*/
+func MakeETag(body []byte, weak bool) string {
+	sum := sha256.Sum256(body)
+	etag := "\"" + hex.EncodeToString(sum[:16]) + "\""
+	if weak {
+		return "W/" + etag
+	}
+	return etag
+}
+
+/*
+ParseETags Function
+*/
+func ParseETags(header string) []string {
+	etags := []string{}
+	for len(header) > 0 {
+		header = strings.TrimLeft(header, " \t,")
+		if len(header) < 1 {
+			break
+		}
+		if header[0] == '*' {
+			etags = append(etags, "*")
+			header = header[1:]
+			continue
+		}
+		start := 0
+		if strings.HasPrefix(header, "W/") {
+			start = 2
+		}
+		if len(header) <= start || header[start] != '"' {
+			break
+		}
+		end := strings.IndexByte(header[start+1:], '"')
+		if end == -1 {
+			break
+		}
+		end += start + 2
+		etags = append(etags, header[:end])
+		header = header[end:]
+	}
+	return etags
+}
+
+/*
+ETagMatch Function
+*/
+func ETagMatch(a string, b string, weak bool) bool {
+	if weak {
+		return strings.TrimPrefix(a, "W/") == strings.TrimPrefix(b, "W/")
+	}
+	return !strings.HasPrefix(a, "W/") && !strings.HasPrefix(b, "W/") && a == b
+}
+
+/*
+HasPreconditions Function
+*/
+func HasPreconditions(r *http.Request) bool {
+	return len(r.Header.Get("If-Match")) > 0 || len(r.Header.Get("If-Unmodified-Since")) > 0 ||
+		len(r.Header.Get("If-None-Match")) > 0
+}
+
+/*
+CheckNotModified Function
+*/
+func CheckNotModified(r *http.Request, etag string, modified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); len(inm) > 0 {
+		if len(etag) < 1 {
+			return false
+		}
+		for _, candidate := range ParseETags(inm) {
+			if candidate == "*" || ETagMatch(candidate, etag, true) {
+				return true
+			}
+		}
+		return false
+	}
+	if modified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	return err == nil && !modified.Truncate(time.Second).After(since)
+}
+
+/*
+CheckPreconditions Function
+*/
+func CheckPreconditions(r *http.Request, etag string, modified time.Time) int {
+	if im := r.Header.Get("If-Match"); len(im) > 0 {
+		for _, candidate := range ParseETags(im) {
+			if (candidate == "*" && len(etag) > 0) || ETagMatch(candidate, etag, false) {
+				return 0
+			}
+		}
+		return http.StatusPreconditionFailed
+	} else if !modified.IsZero() {
+		since, err := http.ParseTime(r.Header.Get("If-Unmodified-Since"))
+		if err == nil && modified.Truncate(time.Second).After(since) {
+			return http.StatusPreconditionFailed
+		}
+	}
+	if inm := r.Header.Get("If-None-Match"); len(inm) > 0 && len(etag) > 0 {
+		for _, candidate := range ParseETags(inm) {
+			if candidate == "*" || ETagMatch(candidate, etag, true) {
+				return http.StatusPreconditionFailed
+			}
+		}
+	}
+	return 0
+}
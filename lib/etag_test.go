@@ -0,0 +1,83 @@
+package lib
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestETagNotModified(t *testing.T) {
+	rt := PlainRouter()
+	rt.AddMiddleware(ETagMiddleware)
+	rt.GET("/", func(ctx *Context) {
+		ctx.Writer.Header("Content-Type", "text/plain")
+		ctx.WriteString("resource")
+	})
+	etag := MakeETag([]byte("resource"), false)
+
+	cases := []struct {
+		ifNoneMatch string
+		status      int
+	}{
+		{"", http.StatusOK},
+		{etag, http.StatusNotModified},
+		{"W/" + etag, http.StatusNotModified},
+		{"\"other\", " + etag, http.StatusNotModified},
+		{"*", http.StatusNotModified},
+		{"\"other\"", http.StatusOK},
+	}
+	for _, tc := range cases {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if len(tc.ifNoneMatch) > 0 {
+			r.Header.Set("If-None-Match", tc.ifNoneMatch)
+		}
+		rec := httptest.NewRecorder()
+		rt.ServeHTTP(rec, r)
+		if rec.Code != tc.status {
+			t.Errorf("%q: expected %d, got %d", tc.ifNoneMatch, tc.status, rec.Code)
+		}
+		if rec.Header().Get("ETag") != etag {
+			t.Errorf("%q: expected ETag %s, got %q", tc.ifNoneMatch, etag, rec.Header().Get("ETag"))
+		}
+		if tc.status == http.StatusNotModified && rec.Body.Len() > 0 {
+			t.Errorf("%q: expected empty 304 body, got %q", tc.ifNoneMatch, rec.Body.String())
+		}
+	}
+}
+
+func TestETagPreconditionFailed(t *testing.T) {
+	modified := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	rt := PlainRouter()
+	rt.AddMiddleware(MakeETagMiddleware(ETagConfig{Resource: func(ctx *Context) (string, time.Time) {
+		return "\"v2\"", modified
+	}}))
+	rt.PUT("/", func(ctx *Context) {
+		ctx.Writer.Status(http.StatusNoContent)
+	})
+
+	cases := []struct {
+		header string
+		value  string
+		status int
+	}{
+		{"If-Match", "\"v2\"", http.StatusNoContent},
+		{"If-Match", "\"v1\"", http.StatusPreconditionFailed},
+		{"If-Match", "W/\"v2\"", http.StatusPreconditionFailed},
+		{"If-Match", "*", http.StatusNoContent},
+		{"If-None-Match", "\"v2\"", http.StatusPreconditionFailed},
+		{"If-None-Match", "*", http.StatusPreconditionFailed},
+		{"If-None-Match", "\"v1\"", http.StatusNoContent},
+		{"If-Unmodified-Since", modified.Format(http.TimeFormat), http.StatusNoContent},
+		{"If-Unmodified-Since", modified.Add(-time.Hour).Format(http.TimeFormat), http.StatusPreconditionFailed},
+	}
+	for _, tc := range cases {
+		r := httptest.NewRequest(http.MethodPut, "/", nil)
+		r.Header.Set(tc.header, tc.value)
+		rec := httptest.NewRecorder()
+		rt.ServeHTTP(rec, r)
+		if rec.Code != tc.status {
+			t.Errorf("%s %q: expected %d, got %d", tc.header, tc.value, tc.status, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package lib
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+/*
+ExplainHeader Constant
+*/
+const ExplainHeader = "X-Route-Explain"
+
+/*
+MatchStep Object
+*/
+type MatchStep struct {
+	Kind    string
+	Matched bool
+	Reason  string
+}
+
+/*
+RouteExplanation Object
+*/
+type RouteExplanation struct {
+	Router   *Router
+	Route    *Route
+	Matched  bool
+	Selected bool
+	Steps    []MatchStep
+}
+
+/*
+Rejection Method
+*/
+func (re *RouteExplanation) Rejection() *MatchStep {
+	for i := range re.Steps {
+		if !re.Steps[i].Matched {
+			return &re.Steps[i]
+		}
+	}
+	return nil
+}
+
+/*
+String Method
+*/
+func (re *RouteExplanation) String() string {
+	name := re.Route.GetName()
+	if len(name) < 1 {
+		name = "-noname-"
+	}
+	state := "miss"
+	if re.Selected {
+		state = "MATCH"
+	} else if re.Matched {
+		state = "shadowed"
+	}
+	return fmt.Sprintf("[%s] %s (%s)", state, re.Route.path, name)
+}
+
+/*
+Explanation Object
+*/
+type Explanation []RouteExplanation
+
+/*
+Selected Method
+*/
+func (ex *Explanation) Selected() *RouteExplanation {
+	for i := range *ex {
+		if (*ex)[i].Selected {
+			return &(*ex)[i]
+		}
+	}
+	return nil
+}
+
+/*
+Lines Method
+*/
+func (ex *Explanation) Lines() []string {
+	lines := []string{}
+	for _, re := range *ex {
+		lines = append(lines, re.String())
+		for _, step := range re.Steps {
+			mark := "+"
+			if !step.Matched {
+				mark = "-"
+			}
+			lines = append(lines, fmt.Sprintf("    %s %s: %s", mark, step.Kind, step.Reason))
+		}
+	}
+	return lines
+}
+
+/*
+Summary Method
+*/
+func (ex *Explanation) Summary() string {
+	if selected := ex.Selected(); selected != nil {
+		return "matched " + selected.Route.path
+	}
+	misses := []string{}
+	for _, re := range *ex {
+		if step := re.Rejection(); step != nil {
+			misses = append(misses, fmt.Sprintf("%s: %s %s", re.Route.path, step.Kind, step.Reason))
+		}
+	}
+	return "no match; " + strings.Join(misses, "; ")
+}
+
+/*
+ExplainMatchers Function
+*/
+func ExplainMatchers(ctx *Context, ms *Matchers) ([]MatchStep, bool) {
+	steps := []MatchStep{}
+	for _, matcher := range *ms {
+		step := MatchStep{Kind: MatcherKind(matcher)}
+		if explainer, ok := matcher.(MatcherExplainer); ok {
+			step.Matched, step.Reason = explainer.Explain(ctx)
+		} else {
+			step.Matched = matcher.Match(ctx)
+			step.Reason = fmt.Sprintf("%s returned %v", reflect.TypeOf(matcher), step.Matched)
+		}
+		steps = append(steps, step)
+		if !step.Matched {
+			return steps, false
+		}
+	}
+	return steps, true
+}
+
+/*
+MatcherKind Function
+*/
+func MatcherKind(matcher Matcher) string {
+	switch matcher.(type) {
+	case *PathMatcher:
+		return "path"
+	case *MethodMatcher:
+		return "method"
+	case *HeaderMatcher:
+		return "header"
+	case *SchemaMatcher:
+		return "schema"
+	case *HostMatcher:
+		return "host"
+	case *PortMatcher:
+		return "port"
+	case *QueryMatcher:
+		return "query"
+	case *CookieMatcher:
+		return "cookie"
+	case *RemoteIPMatcher:
+		return "remote ip"
+	case *ContentTypeMatcher:
+		return "content type"
+	case *AcceptMatcher:
+		return "accept"
+	case *UserAgentMatcher:
+		return "user agent"
+	}
+	return "custom"
+}
+
+/*
+Explain Method
+*/
+func (rg *Router) Explain(r *http.Request) Explanation {
+	ctx := rg.lookupContext(r)
+	explanation := Explanation{}
+	selected := false
+	rg.walkRoutes(func(owner *Router, route *Route) bool {
+		re := RouteExplanation{Router: owner, Route: route}
+		routerSteps, routerMatched := ExplainMatchers(ctx, owner.Matchers())
+		for i := range routerSteps {
+			routerSteps[i].Kind = "router " + routerSteps[i].Kind
+		}
+		re.Steps = routerSteps
+		if routerMatched {
+			routeSteps, routeMatched := ExplainMatchers(ctx, route.Matchers())
+			re.Steps = append(re.Steps, routeSteps...)
+			re.Matched = routeMatched
+		}
+		if re.Matched && !selected {
+			re.Selected = true
+			selected = true
+		}
+		explanation = append(explanation, re)
+		return true
+	})
+	return explanation
+}
+
+/*
+ExplainHandler Method
+*/
+func (rg *Router) ExplainHandler() RequestHandler {
+	return func(ctx *Context) {
+		target := ctx.QueryValue("path")
+		if len(target) < 1 {
+			ctx.Error(http.StatusBadRequest, "Missing path query parameter")
+			return
+		}
+		r, err := http.NewRequest(ctx.QueryValue("method", http.MethodGet), target, nil)
+		if err != nil {
+			ctx.Error(http.StatusBadRequest, err.Error())
+			return
+		}
+		r.Header = ctx.Request.Header.Clone()
+		r.Host = ctx.Request.Host
+		r.RemoteAddr = ctx.Request.RemoteAddr
+		r.TLS = ctx.Request.TLS
+		explanation := rg.Explain(r)
+		ctx.Writer.Header("Content-Type", "text/plain; charset=utf-8")
+		ctx.WriteString(strings.Join(explanation.Lines(), "\n") + "\n")
+	}
+}
+
+/*
+ExplainMiddleware Method
+*/
+func (rg *Router) ExplainMiddleware() Middleware {
+	return func(ctx *Context, next PipelineCallback) {
+		explanation := rg.Explain(ctx.Request)
+		ctx.Writer.Header(ExplainHeader, explanation.Summary())
+		next()
+	}
+}
@@ -3,8 +3,14 @@ package lib
 import (
 	"crypto/rand"
 	"fmt"
+	"math"
 	"math/big"
+	"mime"
+	"net/http"
+	"path"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -52,6 +58,13 @@ func MakePatternRegexp(path string, patterns *Patterns, params Params, addEnd bo
 	return nil
 }
 
+/*
+IntRef Function
+*/
+func IntRef(val int) *int {
+	return &val
+}
+
 /*
 RandomString Function
 */
@@ -79,3 +92,113 @@ func InStringSlice(list []string, val string) bool {
 	}
 	return found
 }
+
+/*
+ParseNumericRange Function
+*/
+func ParseNumericRange(bounds string) (float64, float64, bool) {
+	min, max := math.Inf(-1), math.Inf(1)
+	i := strings.Index(bounds, "..")
+	if i == -1 {
+		return min, max, false
+	}
+	if lower := strings.TrimSpace(bounds[:i]); len(lower) > 0 {
+		num, err := strconv.ParseFloat(lower, 64)
+		if err != nil {
+			return min, max, false
+		}
+		min = num
+	}
+	if upper := strings.TrimSpace(bounds[i+2:]); len(upper) > 0 {
+		num, err := strconv.ParseFloat(upper, 64)
+		if err != nil {
+			return min, max, false
+		}
+		max = num
+	}
+	return min, max, true
+}
+
+/*
+QualityValue Object
+*/
+type QualityValue struct {
+	Value   string
+	Quality float64
+	Params  StringMap
+}
+
+/*
+ParseQualityList Function
+*/
+func ParseQualityList(headers ...string) []QualityValue {
+	list := []QualityValue{}
+	for _, header := range headers {
+		for _, part := range strings.Split(header, ",") {
+			part = strings.TrimSpace(part)
+			if len(part) < 1 {
+				continue
+			}
+			item := QualityValue{Quality: 1, Params: StringMap{}}
+			segments := strings.Split(part, ";")
+			item.Value = strings.ToLower(strings.TrimSpace(segments[0]))
+			for _, segment := range segments[1:] {
+				kv := strings.SplitN(strings.TrimSpace(segment), "=", 2)
+				if len(kv) != 2 {
+					continue
+				}
+				key := strings.ToLower(strings.TrimSpace(kv[0]))
+				val := strings.Trim(strings.TrimSpace(kv[1]), "\"")
+				if key == "q" {
+					q, qErr := strconv.ParseFloat(val, 64)
+					if qErr == nil {
+						item.Quality = q
+					}
+				} else {
+					item.Params[key] = val
+				}
+			}
+			list = append(list, item)
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Quality > list[j].Quality
+	})
+	return list
+}
+
+/*
+MatchMediaType Function
+*/
+func MatchMediaType(pattern string, mediaType string) bool {
+	if pattern == "*" || pattern == "*/*" {
+		return true
+	}
+	matched, err := path.Match(strings.ToLower(pattern), strings.ToLower(mediaType))
+	return err == nil && matched
+}
+
+/*
+ParseMediaType Function
+*/
+func ParseMediaType(val string) (string, StringMap) {
+	mediaType, params, err := mime.ParseMediaType(val)
+	if err != nil {
+		return "", StringMap{}
+	}
+	return mediaType, StringMap(params)
+}
+
+/*
+HeaderHasToken Function
+*/
+func HeaderHasToken(header http.Header, key string, token string) bool {
+	for _, val := range header.Values(key) {
+		for _, item := range strings.Split(val, ",") {
+			if strings.EqualFold(strings.TrimSpace(item), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,167 @@
+package lib
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+/*
+LimiterConfig Object
+*/
+type LimiterConfig struct {
+	MaxInFlight  int
+	MaxQueue     int
+	QueueTimeout time.Duration
+	RetryAfter   time.Duration
+}
+
+/*
+LimiterStats Object
+*/
+type LimiterStats struct {
+	InFlight  int
+	Queued    int
+	MaxQueued int
+	Admitted  uint64
+	Shed      uint64
+	TimedOut  uint64
+}
+
+/*
+ConcurrencyLimiter Object
+*/
+type ConcurrencyLimiter struct {
+	mu     sync.Mutex
+	config LimiterConfig
+	slots  chan struct{}
+	stats  LimiterStats
+}
+
+/*
+Stats Method
+*/
+func (cl *ConcurrencyLimiter) Stats() LimiterStats {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	stats := cl.stats
+	stats.InFlight = len(cl.slots)
+	return stats
+}
+
+/*
+InFlight Method
+*/
+func (cl *ConcurrencyLimiter) InFlight() int {
+	return len(cl.slots)
+}
+
+/*
+Queued Method
+*/
+func (cl *ConcurrencyLimiter) Queued() int {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	return cl.stats.Queued
+}
+
+/*
+Middleware Method
+*/
+func (cl *ConcurrencyLimiter) Middleware() Middleware {
+	return func(ctx *Context, next PipelineCallback) {
+		if !cl.acquire(ctx) {
+			return
+		}
+		defer cl.release()
+		next()
+	}
+}
+
+func (cl *ConcurrencyLimiter) acquire(ctx *Context) bool {
+	select {
+	case cl.slots <- struct{}{}:
+		cl.count(&cl.stats.Admitted)
+		return true
+	default:
+	}
+
+	cl.mu.Lock()
+	if cl.stats.Queued >= cl.config.MaxQueue {
+		cl.stats.Shed++
+		cl.mu.Unlock()
+		cl.shed(ctx)
+		return false
+	}
+	cl.stats.Queued++
+	if cl.stats.Queued > cl.stats.MaxQueued {
+		cl.stats.MaxQueued = cl.stats.Queued
+	}
+	cl.mu.Unlock()
+
+	var expired <-chan time.Time
+	if cl.config.QueueTimeout > 0 {
+		timer := time.NewTimer(cl.config.QueueTimeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+	select {
+	case cl.slots <- struct{}{}:
+		cl.dequeue(&cl.stats.Admitted)
+		return true
+	case <-expired:
+		cl.dequeue(&cl.stats.TimedOut)
+		cl.shed(ctx)
+	case <-ctx.Done():
+		cl.dequeue(nil)
+	}
+	return false
+}
+
+func (cl *ConcurrencyLimiter) release() {
+	<-cl.slots
+}
+
+func (cl *ConcurrencyLimiter) count(counter *uint64) {
+	cl.mu.Lock()
+	*counter++
+	cl.mu.Unlock()
+}
+
+func (cl *ConcurrencyLimiter) dequeue(counter *uint64) {
+	cl.mu.Lock()
+	cl.stats.Queued--
+	if counter != nil {
+		*counter++
+	}
+	cl.mu.Unlock()
+}
+
+func (cl *ConcurrencyLimiter) shed(ctx *Context) {
+	ctx.Writer.Header("Retry-After", strconv.Itoa(int((cl.config.RetryAfter+time.Second-1)/time.Second)))
+	ctx.Error(http.StatusServiceUnavailable, "Server busy")
+}
+
+/*
+NewConcurrencyLimiter Function
+*/
+func NewConcurrencyLimiter(config LimiterConfig) *ConcurrencyLimiter {
+	if config.MaxInFlight < 1 {
+		config.MaxInFlight = 1
+	}
+	if config.MaxQueue < 0 {
+		config.MaxQueue = 0
+	}
+	if config.RetryAfter <= 0 {
+		config.RetryAfter = time.Second
+	}
+	return &ConcurrencyLimiter{config: config, slots: make(chan struct{}, config.MaxInFlight)}
+}
+
+/*
+MakeConcurrencyMiddleware Function
+*/
+func MakeConcurrencyMiddleware(config LimiterConfig) Middleware {
+	return NewConcurrencyLimiter(config).Middleware()
+}
@@ -0,0 +1,88 @@
+package lib
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func limiterRouter(cl *ConcurrencyLimiter, entered chan<- struct{}, block <-chan struct{}) *Router {
+	rt := PlainRouter()
+	rt.AddMiddleware(cl.Middleware())
+	rt.GET("/", func(ctx *Context) {
+		entered <- struct{}{}
+		<-block
+		ctx.WriteString("ok")
+	})
+	return rt
+}
+
+func limiterRequest(rt *Router) <-chan *httptest.ResponseRecorder {
+	result := make(chan *httptest.ResponseRecorder, 1)
+	go func() {
+		rec := httptest.NewRecorder()
+		rt.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		result <- rec
+	}()
+	return result
+}
+
+func waitQueued(t *testing.T, cl *ConcurrencyLimiter, queued int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for cl.Queued() != queued {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d queued requests, got %d", queued, cl.Queued())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestLimiterQueueAndShed(t *testing.T) {
+	entered, block := make(chan struct{}, 2), make(chan struct{})
+	cl := NewConcurrencyLimiter(LimiterConfig{MaxInFlight: 1, MaxQueue: 1, RetryAfter: 1500 * time.Millisecond})
+	rt := limiterRouter(cl, entered, block)
+
+	first := limiterRequest(rt)
+	<-entered
+	second := limiterRequest(rt)
+	waitQueued(t, cl, 1)
+
+	shed := <-limiterRequest(rt)
+	if shed.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected shed request to get 503, got %d", shed.Code)
+	}
+	if shed.Header().Get("Retry-After") != "2" {
+		t.Errorf("expected Retry-After rounded up to 2, got %q", shed.Header().Get("Retry-After"))
+	}
+
+	close(block)
+	for _, result := range []<-chan *httptest.ResponseRecorder{first, second} {
+		if rec := <-result; rec.Code != http.StatusOK || rec.Body.String() != "ok" {
+			t.Errorf("expected admitted request to succeed, got %d %q", rec.Code, rec.Body.String())
+		}
+	}
+	stats := cl.Stats()
+	if stats.Admitted != 2 || stats.Shed != 1 || stats.MaxQueued != 1 || stats.Queued != 0 || stats.InFlight != 0 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestLimiterQueueTimeout(t *testing.T) {
+	entered, block := make(chan struct{}, 1), make(chan struct{})
+	cl := NewConcurrencyLimiter(LimiterConfig{MaxInFlight: 1, MaxQueue: 1, QueueTimeout: 10 * time.Millisecond})
+	rt := limiterRouter(cl, entered, block)
+
+	first := limiterRequest(rt)
+	<-entered
+	rec := <-limiterRequest(rt)
+	if rec.Code != http.StatusServiceUnavailable || rec.Header().Get("Retry-After") != "1" {
+		t.Errorf("expected 503 with Retry-After 1, got %d %q", rec.Code, rec.Header().Get("Retry-After"))
+	}
+	close(block)
+	<-first
+	if stats := cl.Stats(); stats.TimedOut != 1 || stats.Admitted != 1 || stats.Queued != 0 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
@@ -1,6 +1,9 @@
 package lib
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+)
 
 /*
 StringMap Object
@@ -51,6 +54,42 @@ func (d *ObjectMap) GetString(key string) string {
 	return fmt.Sprintf("%v", val)
 }
 
+/*
+GetInt Method
+*/
+func (d *ObjectMap) GetInt(key string, def ...int) int {
+	switch val := d.Get(key).(type) {
+	case int:
+		return val
+	case int64:
+		return int(val)
+	case int32:
+		return int(val)
+	case string:
+		if num, err := strconv.Atoi(val); err == nil {
+			return num
+		}
+	}
+	if len(def) > 0 {
+		return def[0]
+	}
+	return 0
+}
+
+/*
+GetBool Method
+*/
+func (d *ObjectMap) GetBool(key string) bool {
+	switch val := d.Get(key).(type) {
+	case bool:
+		return val
+	case string:
+		b, _ := strconv.ParseBool(val)
+		return b
+	}
+	return false
+}
+
 /*
 Has Method
 */
@@ -63,8 +102,9 @@ func (d *ObjectMap) Has(key string) bool {
 Clear Method
 */
 func (d *ObjectMap) Clear() {
-	newMap := &ObjectMap{}
-	*d = *newMap
+	for key := range *d {
+		delete(*d, key)
+	}
 }
 
 /*
@@ -59,6 +59,13 @@ func (ms *Matchers) Matches(ctx *Context) bool {
 	return matches
 }
 
+/*
+MatcherExplainer Interface
+*/
+type MatcherExplainer interface {
+	Explain(*Context) (bool, string)
+}
+
 /*
 MatcherMixin Object
 */
@@ -135,3 +142,11 @@ MakeMatcher Function
 func MakeMatcher(handler MatchHandler) Matcher {
 	return &MatcherWrapper{matcher: handler}
 }
+
+/*
+Explain Method
+*/
+func (nm *MatcherWrapper) Explain(ctx *Context) (bool, string) {
+	matched := nm.matcher(ctx)
+	return matched, fmt.Sprintf("custom matcher returned %v", matched)
+}
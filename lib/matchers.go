@@ -1,7 +1,10 @@
 package lib
 
 import (
+	"fmt"
+	"net"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -10,6 +13,24 @@ MatchCaseEmpty Constant
 */
 const MatchCaseEmpty = ""
 
+/*
+MatchCase Constants
+*/
+const (
+	MatchCaseEqual    = "equal"
+	MatchCaseFold     = "fold"
+	MatchCaseRegexp   = "regexp"
+	MatchCaseList     = "list"
+	MatchCasePrefix   = "prefix"
+	MatchCaseSuffix   = "suffix"
+	MatchCaseGlob     = "glob"
+	MatchCaseRange    = "range"
+	MatchCaseContains = "contains"
+	MatchCaseCIDR     = "cidr"
+	MatchCasePresent  = "present"
+	MatchCaseAbsent   = "absent"
+)
+
 /*
 MatcherItem Object
 */
@@ -17,32 +38,90 @@ type MatcherItem struct {
 	value   string
 	compare string
 	reg     *regexp.Regexp
+	glob    *regexp.Regexp
+	set     map[string]struct{}
+	nets    []*net.IPNet
+	min     float64
+	max     float64
+	bounded bool
+}
+
+func (mi *MatcherItem) prepare() {
+	mi.set = nil
+	mi.nets = nil
+	mi.glob = nil
+	mi.bounded = false
+	switch mi.compare {
+	case MatchCaseRegexp:
+		if mi.reg == nil && len(mi.value) > 0 {
+			reg, regErr := regexp.Compile(mi.value)
+			if regErr == nil {
+				mi.reg = reg
+			}
+		}
+	case MatchCaseList:
+		mi.set = map[string]struct{}{}
+		for _, item := range strings.Split(mi.value, "|") {
+			mi.set[item] = struct{}{}
+		}
+	case MatchCaseGlob:
+		mi.glob = compileGlob(mi.value)
+	case MatchCaseRange:
+		mi.min, mi.max, mi.bounded = ParseNumericRange(mi.value)
+	case MatchCaseCIDR:
+		for _, cidr := range strings.Split(mi.value, "|") {
+			if network := ParseCIDR(cidr); network != nil {
+				mi.nets = append(mi.nets, network)
+			}
+		}
+	}
 }
 
 /*
 Valid Method
 */
 func (mi *MatcherItem) Valid(val string) bool {
+	switch mi.compare {
+	case MatchCasePresent:
+		return val != ""
+	case MatchCaseAbsent:
+		return val == ""
+	}
 	matches := false
 	if val != "" {
 		if len(mi.value) > 0 {
 			switch mi.compare {
-			case "equal":
+			case MatchCaseEqual:
 				matches = val == mi.value
-			case "regexp":
-				if mi.reg == nil {
-					reg, regErr := regexp.Compile(mi.value)
-					if regErr == nil {
-						mi.reg = reg
-					}
-				}
+			case MatchCaseFold:
+				matches = strings.EqualFold(val, mi.value)
+			case MatchCaseRegexp:
 				if mi.reg != nil {
 					matches = mi.reg.MatchString(val)
 				}
-			case "list":
-				list := strings.Split(mi.value, "|")
-				if list != nil && len(list) > 0 {
-					matches = InStringSlice(list, val)
+			case MatchCaseList:
+				_, matches = mi.set[val]
+			case MatchCasePrefix:
+				matches = strings.HasPrefix(val, mi.value)
+			case MatchCaseSuffix:
+				matches = strings.HasSuffix(val, mi.value)
+			case MatchCaseGlob:
+				matches = mi.glob != nil && mi.glob.MatchString(val)
+			case MatchCaseRange:
+				if mi.bounded {
+					num, numErr := strconv.ParseFloat(strings.TrimSpace(val), 64)
+					matches = numErr == nil && num >= mi.min && num <= mi.max
+				}
+			case MatchCaseContains:
+				matches = strings.Contains(val, mi.value)
+			case MatchCaseCIDR:
+				if ip := net.ParseIP(val); ip != nil {
+					for _, network := range mi.nets {
+						if network.Contains(ip) {
+							matches = true
+							break
+						}
+					}
 				}
 			default:
 				matches = false
@@ -54,12 +133,43 @@ func (mi *MatcherItem) Valid(val string) bool {
 	return matches
 }
 
+func (mi *MatcherItem) presence(present bool) (bool, bool) {
+	switch mi.compare {
+	case MatchCasePresent:
+		return present, true
+	case MatchCaseAbsent:
+		return !present, true
+	}
+	return false, false
+}
+
+func compileGlob(pattern string) *regexp.Regexp {
+	var rgText strings.Builder
+	rgText.WriteString("^")
+	for _, char := range pattern {
+		switch char {
+		case '*':
+			rgText.WriteString("(?s:.*)")
+		case '?':
+			rgText.WriteString("(?s:.)")
+		default:
+			rgText.WriteString(regexp.QuoteMeta(string(char)))
+		}
+	}
+	rgText.WriteString("$")
+	reg, regErr := regexp.Compile(rgText.String())
+	if regErr != nil {
+		return nil
+	}
+	return reg
+}
+
 /*
 SetRegexp Method
 */
 func (mi *MatcherItem) SetRegexp(reg *regexp.Regexp) {
 	mi.reg = reg
-	mi.SetCompare("regexp")
+	mi.SetCompare(MatchCaseRegexp)
 }
 
 /*
@@ -84,6 +194,68 @@ SetCompare Method
 */
 func (mi *MatcherItem) SetCompare(compare string) {
 	mi.compare = compare
+	mi.prepare()
+}
+
+/*
+SetValue Method
+*/
+func (mi *MatcherItem) SetValue(val string) {
+	mi.value = val
+	if mi.compare == MatchCaseRegexp {
+		mi.reg = nil
+	}
+	mi.prepare()
+}
+
+/*
+GetCompare Method
+*/
+func (mi *MatcherItem) GetCompare() string {
+	return mi.compare
+}
+
+/*
+GetValue Method
+*/
+func (mi *MatcherItem) GetValue() string {
+	return mi.value
+}
+
+/*
+Describe Method
+*/
+func (mi *MatcherItem) Describe() string {
+	switch mi.compare {
+	case MatchCasePresent, MatchCaseAbsent:
+		return mi.compare
+	}
+	if mi.compare == MatchCaseRegexp && mi.reg != nil {
+		return fmt.Sprintf("%s %q", mi.compare, mi.reg.String())
+	}
+	if len(mi.value) < 1 {
+		return "any"
+	}
+	if len(mi.compare) < 1 {
+		return fmt.Sprintf("%q", mi.value)
+	}
+	return fmt.Sprintf("%s %q", mi.compare, mi.value)
+}
+
+func (mi *MatcherItem) explain(key string, val string) (bool, string) {
+	if len(key) > 0 {
+		return mi.Valid(val), fmt.Sprintf("%s=%q, want %s", key, val, mi.Describe())
+	}
+	return mi.Valid(val), fmt.Sprintf("%q, want %s", val, mi.Describe())
+}
+
+/*
+NewMatcherItem Function
+*/
+func NewMatcherItem(compare string, val string) MatcherItem {
+	mi := MatcherItem{value: val, compare: compare}
+	mi.prepare()
+	return mi
 }
 
 /*
@@ -98,14 +270,25 @@ type HeaderMatcher struct {
 Match Method
 */
 func (hm *HeaderMatcher) Match(ctx *Context) bool {
+	if matches, checked := hm.presence(len(ctx.Request.Header.Values(hm.header)) > 0); checked {
+		return matches
+	}
 	return hm.Valid(ctx.Request.Header.Get(hm.header))
 }
 
+/*
+Explain Method
+*/
+func (hm *HeaderMatcher) Explain(ctx *Context) (bool, string) {
+	_, reason := hm.explain(hm.header, ctx.Request.Header.Get(hm.header))
+	return hm.Match(ctx), reason
+}
+
 /*
 HeaderMatch Function
 */
 func HeaderMatch(key string, val string) *HeaderMatcher {
-	return &HeaderMatcher{MatcherItem: MatcherItem{value: val, compare: "equal"}, header: key}
+	return &HeaderMatcher{MatcherItem: NewMatcherItem(MatchCaseEqual, val), header: key}
 }
 
 /*
@@ -117,6 +300,27 @@ func HeaderMatchRegexp(key string, val string) *HeaderMatcher {
 	return matcher
 }
 
+/*
+HeaderMatchCase Function
+*/
+func HeaderMatchCase(key string, compare string, val string) *HeaderMatcher {
+	return &HeaderMatcher{MatcherItem: NewMatcherItem(compare, val), header: key}
+}
+
+/*
+HeaderPresent Function
+*/
+func HeaderPresent(key string) *HeaderMatcher {
+	return HeaderMatchCase(key, MatchCasePresent, "")
+}
+
+/*
+HeaderAbsent Function
+*/
+func HeaderAbsent(key string) *HeaderMatcher {
+	return HeaderMatchCase(key, MatchCaseAbsent, "")
+}
+
 /*
 SchemaMatcher Object
 */
@@ -131,11 +335,18 @@ func (sm *SchemaMatcher) Match(ctx *Context) bool {
 	return sm.Valid(ctx.Schema)
 }
 
+/*
+Explain Method
+*/
+func (sm *SchemaMatcher) Explain(ctx *Context) (bool, string) {
+	return sm.explain("", ctx.Schema)
+}
+
 /*
 SchemaMatch Function
 */
 func SchemaMatch(schema string) *SchemaMatcher {
-	return &SchemaMatcher{MatcherItem{value: schema, compare: "equal"}}
+	return &SchemaMatcher{NewMatcherItem(MatchCaseEqual, schema)}
 }
 
 /*
@@ -147,6 +358,13 @@ func SchemaMatchRegexp(schema string) *SchemaMatcher {
 	return matcher
 }
 
+/*
+SchemaMatchCase Function
+*/
+func SchemaMatchCase(compare string, schema string) *SchemaMatcher {
+	return &SchemaMatcher{NewMatcherItem(compare, schema)}
+}
+
 /*
 HostMatcher Object
 */
@@ -161,11 +379,18 @@ func (hm *HostMatcher) Match(ctx *Context) bool {
 	return hm.Valid(ctx.Host)
 }
 
+/*
+Explain Method
+*/
+func (hm *HostMatcher) Explain(ctx *Context) (bool, string) {
+	return hm.explain("", ctx.Host)
+}
+
 /*
 HostMatch Function
 */
 func HostMatch(host string) *HostMatcher {
-	return &HostMatcher{MatcherItem{value: host, compare: "equal"}}
+	return &HostMatcher{NewMatcherItem(MatchCaseEqual, host)}
 }
 
 /*
@@ -177,6 +402,13 @@ func HostMatchRegexp(host string) *HostMatcher {
 	return matcher
 }
 
+/*
+HostMatchCase Function
+*/
+func HostMatchCase(compare string, host string) *HostMatcher {
+	return &HostMatcher{NewMatcherItem(compare, host)}
+}
+
 /*
 PortMatcher Object
 */
@@ -191,11 +423,18 @@ func (hm *PortMatcher) Match(ctx *Context) bool {
 	return hm.Valid(ctx.Port)
 }
 
+/*
+Explain Method
+*/
+func (hm *PortMatcher) Explain(ctx *Context) (bool, string) {
+	return hm.explain("", ctx.Port)
+}
+
 /*
 PortMatch Function
 */
 func PortMatch(port string) *PortMatcher {
-	return &PortMatcher{MatcherItem{value: port, compare: "equal"}}
+	return &PortMatcher{NewMatcherItem(MatchCaseEqual, port)}
 }
 
 /*
@@ -207,6 +446,20 @@ func PortMatchRegexp(port string) *PortMatcher {
 	return matcher
 }
 
+/*
+PortMatchCase Function
+*/
+func PortMatchCase(compare string, port string) *PortMatcher {
+	return &PortMatcher{NewMatcherItem(compare, port)}
+}
+
+/*
+PortMatchRange Function
+*/
+func PortMatchRange(min string, max string) *PortMatcher {
+	return PortMatchCase(MatchCaseRange, min+".."+max)
+}
+
 /*
 QueryMatcher Object
 */
@@ -219,14 +472,25 @@ type QueryMatcher struct {
 Match Method
 */
 func (qm *QueryMatcher) Match(ctx *Context) bool {
+	if matches, checked := qm.presence(ctx.Request.URL.Query().Has(qm.key)); checked {
+		return matches
+	}
 	return qm.Valid(ctx.QueryValue(qm.key))
 }
 
+/*
+Explain Method
+*/
+func (qm *QueryMatcher) Explain(ctx *Context) (bool, string) {
+	_, reason := qm.explain(qm.key, ctx.QueryValue(qm.key))
+	return qm.Match(ctx), reason
+}
+
 /*
 QueryMatch Function
 */
 func QueryMatch(key string, val string) *QueryMatcher {
-	return &QueryMatcher{MatcherItem: MatcherItem{value: val, compare: "equal"}, key: key}
+	return &QueryMatcher{MatcherItem: NewMatcherItem(MatchCaseEqual, val), key: key}
 }
 
 /*
@@ -238,11 +502,251 @@ func QueryMatchRegexp(key string, val string) *QueryMatcher {
 	return matcher
 }
 
+/*
+QueryMatchCase Function
+*/
+func QueryMatchCase(key string, compare string, val string) *QueryMatcher {
+	return &QueryMatcher{MatcherItem: NewMatcherItem(compare, val), key: key}
+}
+
+/*
+QueryPresent Function
+*/
+func QueryPresent(key string) *QueryMatcher {
+	return QueryMatchCase(key, MatchCasePresent, "")
+}
+
+/*
+QueryAbsent Function
+*/
+func QueryAbsent(key string) *QueryMatcher {
+	return QueryMatchCase(key, MatchCaseAbsent, "")
+}
+
+/*
+CookieMatcher Object
+*/
+type CookieMatcher struct {
+	MatcherItem
+	name string
+}
+
+/*
+Match Method
+*/
+func (cm *CookieMatcher) Match(ctx *Context) bool {
+	val := ""
+	cookie, err := ctx.Request.Cookie(cm.name)
+	if err == nil {
+		val = cookie.Value
+	}
+	if matches, checked := cm.presence(err == nil); checked {
+		return matches
+	}
+	return cm.Valid(val)
+}
+
+/*
+Explain Method
+*/
+func (cm *CookieMatcher) Explain(ctx *Context) (bool, string) {
+	val := ""
+	cookie, err := ctx.Request.Cookie(cm.name)
+	if err == nil {
+		val = cookie.Value
+	}
+	_, reason := cm.explain(cm.name, val)
+	return cm.Match(ctx), reason
+}
+
+/*
+CookieMatch Function
+*/
+func CookieMatch(name string, val string) *CookieMatcher {
+	return &CookieMatcher{MatcherItem: NewMatcherItem(MatchCaseEqual, val), name: name}
+}
+
+/*
+CookieMatchRegexp Function
+*/
+func CookieMatchRegexp(name string, val string) *CookieMatcher {
+	matcher := CookieMatch(name, val)
+	matcher.MatcherItem.SetRegexpCompile(val)
+	return matcher
+}
+
+/*
+CookieMatchCase Function
+*/
+func CookieMatchCase(name string, compare string, val string) *CookieMatcher {
+	return &CookieMatcher{MatcherItem: NewMatcherItem(compare, val), name: name}
+}
+
+/*
+RemoteIPMatcher Object
+*/
+type RemoteIPMatcher struct {
+	MatcherItem
+}
+
+/*
+Match Method
+*/
+func (rm *RemoteIPMatcher) Match(ctx *Context) bool {
+	return rm.Valid(ctx.ClientIP())
+}
+
+/*
+Explain Method
+*/
+func (rm *RemoteIPMatcher) Explain(ctx *Context) (bool, string) {
+	return rm.explain("", ctx.ClientIP())
+}
+
+/*
+RemoteIPMatch Function
+*/
+func RemoteIPMatch(cidrs ...string) *RemoteIPMatcher {
+	return &RemoteIPMatcher{NewMatcherItem(MatchCaseCIDR, strings.Join(cidrs, "|"))}
+}
+
+/*
+ContentTypeMatcher Object
+*/
+type ContentTypeMatcher struct {
+	MatcherItem
+	params StringMap
+}
+
+/*
+Match Method
+*/
+func (cm *ContentTypeMatcher) Match(ctx *Context) bool {
+	mediaType, params := ParseMediaType(ctx.Request.Header.Get("Content-Type"))
+	if !cm.Valid(mediaType) {
+		return false
+	}
+	for key, val := range cm.params {
+		if !strings.EqualFold(params[key], val) {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+Explain Method
+*/
+func (cm *ContentTypeMatcher) Explain(ctx *Context) (bool, string) {
+	reason := fmt.Sprintf("%q, want %s", ctx.Request.Header.Get("Content-Type"), cm.Describe())
+	if len(cm.params) > 0 {
+		reason += fmt.Sprintf(" with %v", map[string]string(cm.params))
+	}
+	return cm.Match(ctx), reason
+}
+
+/*
+ContentTypeMatch Function
+*/
+func ContentTypeMatch(contentType string) *ContentTypeMatcher {
+	mediaType, params := ParseMediaType(contentType)
+	return &ContentTypeMatcher{MatcherItem: NewMatcherItem(MatchCaseGlob, mediaType), params: params}
+}
+
+/*
+AcceptMatcher Object
+*/
+type AcceptMatcher struct {
+	MatcherItem
+}
+
+/*
+Match Method
+*/
+func (am *AcceptMatcher) Match(ctx *Context) bool {
+	header := ctx.Request.Header.Get("Accept")
+	if len(header) < 1 {
+		return true
+	}
+	for _, item := range ParseQualityList(header) {
+		if item.Quality <= 0 {
+			continue
+		}
+		if strings.Contains(item.Value, "*") {
+			if MatchMediaType(item.Value, am.value) {
+				return true
+			}
+		} else if am.Valid(item.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+Explain Method
+*/
+func (am *AcceptMatcher) Explain(ctx *Context) (bool, string) {
+	return am.Match(ctx), fmt.Sprintf("%q, want %q", ctx.Request.Header.Get("Accept"), am.value)
+}
+
+/*
+AcceptMatch Function
+*/
+func AcceptMatch(mediaType string) *AcceptMatcher {
+	return &AcceptMatcher{NewMatcherItem(MatchCaseFold, strings.ToLower(mediaType))}
+}
+
+/*
+UserAgentMatcher Object
+*/
+type UserAgentMatcher struct {
+	MatcherItem
+}
+
+/*
+Match Method
+*/
+func (um *UserAgentMatcher) Match(ctx *Context) bool {
+	return um.Valid(ctx.Request.UserAgent())
+}
+
+/*
+Explain Method
+*/
+func (um *UserAgentMatcher) Explain(ctx *Context) (bool, string) {
+	return um.explain("", ctx.Request.UserAgent())
+}
+
+/*
+UserAgentMatch Function
+*/
+func UserAgentMatch(val string) *UserAgentMatcher {
+	return &UserAgentMatcher{NewMatcherItem(MatchCaseContains, val)}
+}
+
+/*
+UserAgentMatchRegexp Function
+*/
+func UserAgentMatchRegexp(val string) *UserAgentMatcher {
+	matcher := UserAgentMatch(val)
+	matcher.MatcherItem.SetRegexpCompile(val)
+	return matcher
+}
+
+/*
+UserAgentMatchCase Function
+*/
+func UserAgentMatchCase(compare string, val string) *UserAgentMatcher {
+	return &UserAgentMatcher{NewMatcherItem(compare, val)}
+}
+
 /*
 MethodMatcher Object
 */
 type MethodMatcher struct {
 	MatcherItem
+	methods []string
 }
 
 /*
@@ -252,35 +756,42 @@ func (mm *MethodMatcher) Match(ctx *Context) bool {
 	return mm.Valid(ctx.Method)
 }
 
+/*
+Explain Method
+*/
+func (mm *MethodMatcher) Explain(ctx *Context) (bool, string) {
+	return mm.explain("", ctx.Method)
+}
+
 /*
 Add Method
 */
 func (mm *MethodMatcher) Add(method string) {
-	methods := mm.Methods()
-	methods = append(methods, method)
-	mm.MatcherItem.value = strings.Join(methods, "|")
+	mm.Set(append(mm.Methods(), method)...)
 }
 
 /*
 Set Method
 */
 func (mm *MethodMatcher) Set(methods ...string) {
-	mm.MatcherItem.value = strings.Join(methods, "|")
+	mm.methods = append([]string{}, methods...)
+	mm.MatcherItem.SetValue(strings.Join(methods, "|"))
 }
 
 /*
-Set Method
+Methods Method
 */
 func (mm *MethodMatcher) Methods() []string {
-	return strings.Split(mm.MatcherItem.value, "|")
+	return append([]string{}, mm.methods...)
 }
 
 /*
 MethodMatch Function
 */
 func MethodMatch(methods ...string) *MethodMatcher {
-	methodsStr := strings.Join(methods, "|")
-	return &MethodMatcher{MatcherItem{value: methodsStr, compare: "list"}}
+	matcher := &MethodMatcher{MatcherItem: NewMatcherItem(MatchCaseList, "")}
+	matcher.Set(methods...)
+	return matcher
 }
 
 /*
@@ -289,28 +800,45 @@ PathMatcher Object
 type PathMatcher struct {
 	MatcherItem
 	formats []string
+	capture bool
 }
 
 /*
 Match Method
 */
 func (pm *PathMatcher) Match(ctx *Context) bool {
+	if pm.capture && pm.compare == MatchCaseRegexp && pm.reg != nil {
+		values := pm.reg.FindStringSubmatch(ctx.Path)
+		if values == nil {
+			return false
+		}
+		ctx.pathMatcher = pm
+		ctx.pathValues = values
+		return true
+	}
 	return pm.Valid(ctx.Path)
 }
 
+/*
+Explain Method
+*/
+func (pm *PathMatcher) Explain(ctx *Context) (bool, string) {
+	return pm.explain("", ctx.Path)
+}
+
 /*
 SetRegexp Method
 */
 func (pm *PathMatcher) SetRegexp(reg *regexp.Regexp) {
 	pm.MatcherItem.reg = reg
-	pm.MatcherItem.compare = "regexp"
+	pm.MatcherItem.SetCompare(MatchCaseRegexp)
 }
 
 /*
 SetCompare Method
 */
 func (pm *PathMatcher) SetCompare() {
-	pm.MatcherItem.compare = "equal"
+	pm.MatcherItem.SetCompare(MatchCaseEqual)
 }
 
 /*
@@ -0,0 +1,114 @@
+package lib
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMatcherItemModes(t *testing.T) {
+	cases := []struct {
+		compare string
+		value   string
+		input   string
+		want    bool
+	}{
+		{MatchCaseEqual, "abc", "abc", true},
+		{MatchCaseEqual, "abc", "ABC", false},
+		{MatchCaseFold, "abc", "ABC", true},
+		{MatchCaseFold, "abc", "abd", false},
+		{MatchCasePrefix, "/api", "/api/users", true},
+		{MatchCasePrefix, "/api", "/web", false},
+		{MatchCaseSuffix, ".json", "data.json", true},
+		{MatchCaseSuffix, ".json", "data.xml", false},
+		{MatchCaseGlob, "*Chrome*", "Mozilla/5.0 Chrome/120", true},
+		{MatchCaseGlob, "v?.*", "v1.2", true},
+		{MatchCaseGlob, "*Chrome*", "curl/8.0", false},
+		{MatchCaseGlob, "a.b", "axb", false},
+		{MatchCaseRange, "10..20", "15", true},
+		{MatchCaseRange, "10..20", "20", true},
+		{MatchCaseRange, "10..20", "21", false},
+		{MatchCaseRange, "10..", "1000", true},
+		{MatchCaseRange, "10..20", "abc", false},
+		{MatchCaseList, "GET|POST", "POST", true},
+		{MatchCaseList, "GET|POST", "PUT", false},
+		{MatchCaseRegexp, "^[0-9]+$", "123", true},
+		{MatchCaseRegexp, "^[0-9]+$", "12a", false},
+		{MatchCasePresent, "", "x", true},
+		{MatchCasePresent, "", "", false},
+		{MatchCaseAbsent, "", "", true},
+		{MatchCaseAbsent, "", "x", false},
+	}
+	for _, tc := range cases {
+		mi := NewMatcherItem(tc.compare, tc.value)
+		if got := mi.Valid(tc.input); got != tc.want {
+			t.Errorf("%s %q on %q: got %v, want %v", tc.compare, tc.value, tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestPresenceMatchers(t *testing.T) {
+	cases := []struct {
+		matcher Matcher
+		target  string
+		header  http.Header
+		want    bool
+	}{
+		{QueryPresent("debug"), "/?debug", nil, true},
+		{QueryPresent("debug"), "/?debug=", nil, true},
+		{QueryPresent("debug"), "/?other=1", nil, false},
+		{QueryAbsent("debug"), "/?debug", nil, false},
+		{QueryAbsent("debug"), "/", nil, true},
+		{HeaderPresent("X-Flag"), "/", http.Header{"X-Flag": {""}}, true},
+		{HeaderPresent("X-Flag"), "/", nil, false},
+		{HeaderAbsent("X-Flag"), "/", http.Header{"X-Flag": {""}}, false},
+		{HeaderAbsent("X-Flag"), "/", nil, true},
+	}
+	for _, tc := range cases {
+		r := httptest.NewRequest(http.MethodGet, tc.target, nil)
+		for key, vals := range tc.header {
+			r.Header[key] = vals
+		}
+		ctx := NewContext(httptest.NewRecorder(), r)
+		if got := tc.matcher.Match(ctx); got != tc.want {
+			t.Errorf("%T on %s %v: got %v, want %v", tc.matcher, tc.target, tc.header, got, tc.want)
+		}
+	}
+}
+
+func TestBuiltinMatchers(t *testing.T) {
+	cases := []struct {
+		name    string
+		matcher Matcher
+		setup   func(*http.Request)
+		want    bool
+	}{
+		{"cookie equal", CookieMatch("session", "abc"), func(r *http.Request) { r.Header.Set("Cookie", "session=abc") }, true},
+		{"cookie mismatch", CookieMatch("session", "abc"), func(r *http.Request) { r.Header.Set("Cookie", "session=xyz") }, false},
+		{"cookie empty value present", CookieMatchCase("a", MatchCasePresent, ""), func(r *http.Request) { r.Header.Set("Cookie", "a=") }, true},
+		{"cookie missing absent", CookieMatchCase("a", MatchCaseAbsent, ""), func(r *http.Request) {}, true},
+		{"cookie empty value not absent", CookieMatchCase("a", MatchCaseAbsent, ""), func(r *http.Request) { r.Header.Set("Cookie", "a=") }, false},
+		{"remote ip in cidr", RemoteIPMatch("192.0.2.0/24"), func(r *http.Request) { r.RemoteAddr = "192.0.2.10:1234" }, true},
+		{"remote ip outside cidr", RemoteIPMatch("192.0.2.0/24"), func(r *http.Request) { r.RemoteAddr = "198.51.100.1:1234" }, false},
+		{"remote ip single address", RemoteIPMatch("10.0.0.0/8", "2001:db8::1"), func(r *http.Request) { r.RemoteAddr = "[2001:db8::1]:80" }, true},
+		{"content type with params", ContentTypeMatch("application/json"), func(r *http.Request) { r.Header.Set("Content-Type", "application/json; charset=utf-8") }, true},
+		{"content type glob", ContentTypeMatch("text/*"), func(r *http.Request) { r.Header.Set("Content-Type", "text/csv") }, true},
+		{"content type param mismatch", ContentTypeMatch("text/plain; charset=utf-8"), func(r *http.Request) { r.Header.Set("Content-Type", "text/plain; charset=latin1") }, false},
+		{"content type other", ContentTypeMatch("application/json"), func(r *http.Request) { r.Header.Set("Content-Type", "text/html") }, false},
+		{"accept exact", AcceptMatch("application/json"), func(r *http.Request) { r.Header.Set("Accept", "text/html, application/json;q=0.8") }, true},
+		{"accept wildcard", AcceptMatch("application/json"), func(r *http.Request) { r.Header.Set("Accept", "application/*") }, true},
+		{"accept refused", AcceptMatch("application/json"), func(r *http.Request) { r.Header.Set("Accept", "text/html, application/json;q=0") }, false},
+		{"accept missing header", AcceptMatch("application/json"), func(r *http.Request) {}, true},
+		{"user agent contains", UserAgentMatch("Chrome"), func(r *http.Request) { r.Header.Set("User-Agent", "Mozilla/5.0 Chrome/120") }, true},
+		{"user agent regexp", UserAgentMatchRegexp("^curl/"), func(r *http.Request) { r.Header.Set("User-Agent", "curl/8.0") }, true},
+		{"user agent mismatch", UserAgentMatch("Chrome"), func(r *http.Request) { r.Header.Set("User-Agent", "curl/8.0") }, false},
+	}
+	for _, tc := range cases {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		tc.setup(r)
+		ctx := NewContext(httptest.NewRecorder(), r)
+		if got := tc.matcher.Match(ctx); got != tc.want {
+			t.Errorf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
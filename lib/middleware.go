@@ -5,6 +5,22 @@ Middleware Object
 */
 type Middleware func(*Context, PipelineCallback)
 
+/*
+ErrorMiddleware Object
+*/
+type ErrorMiddleware func(*Context, PipelineCallback) error
+
+/*
+Middleware Method
+*/
+func (mw ErrorMiddleware) Middleware() Middleware {
+	return func(ctx *Context, next PipelineCallback) {
+		if err := mw(ctx, next); err != nil {
+			ctx.Fail(err)
+		}
+	}
+}
+
 /*
 Middlewares Object
 */
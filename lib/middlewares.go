@@ -1,6 +1,7 @@
 package lib
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 )
@@ -10,19 +11,29 @@ LoggerMiddleware Function
 */
 func LoggerMiddleware(ctx *Context, next PipelineCallback) {
 	next()
-	fmt.Printf("%s: %s [%v]\n", ctx.Method, ctx.Path, ctx.Matched)
+	fmt.Printf("%s: %s [%v] %d %dB %v\n", ctx.Method, ctx.Path, ctx.Matched, ctx.ResponseStatus(), ctx.ResponseSize(), ctx.Elapsed())
 }
 
 /*
 PrettyErrorsMiddleware Function
 */
 func PrettyErrorsMiddleware(ctx *Context, next PipelineCallback) {
-	next()
-	if !ctx.Errors.Empty() {
-		msgs := []string{}
-		for _, err := range *ctx.Errors {
-			msgs = append(msgs, fmt.Sprintf("[%v] => %s", err.Code, err.Message))
+	defer func() {
+		if r := recover(); r != nil {
+			ctx.Recover(r)
+		}
+		if !ctx.Errors.Empty() {
+			msgs := []string{}
+			for _, err := range *ctx.Errors {
+				msg := err.Error()
+				var pe *PanicError
+				if errors.As(err, &pe) {
+					msg += "\n" + string(pe.Stack)
+				}
+				msgs = append(msgs, msg)
+			}
+			fmt.Printf("Errors recovered:\n%s\n\n", strings.Join(msgs, "\n"))
 		}
-		fmt.Printf("Errors recovered:\n%s\n\n", strings.Join(msgs, "\n"))
-	}
+	}()
+	next()
 }
@@ -0,0 +1,100 @@
+package lib
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+)
+
+/*
+PanicHandler Function
+*/
+type PanicHandler func(*Context, *PanicError)
+
+/*
+PanicResponder Interface
+*/
+type PanicResponder interface {
+	HandlePanic(*Context, *PanicError)
+}
+
+/*
+PanicError Object
+*/
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+/*
+Error Method
+*/
+func (pe *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", pe.Value)
+}
+
+/*
+Unwrap Method
+*/
+func (pe *PanicError) Unwrap() error {
+	if err, ok := pe.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+/*
+NewPanicError Function
+*/
+func NewPanicError(value interface{}, stack []byte) *PanicError {
+	if pe, ok := value.(*PanicError); ok {
+		return pe
+	}
+	if stack == nil {
+		stack = debug.Stack()
+	}
+	return &PanicError{Value: value, Stack: stack}
+}
+
+/*
+Recover Method
+*/
+func (ctx *Context) Recover(value interface{}) *PanicError {
+	pe := NewPanicError(value, debug.Stack())
+	if responder, ok := ctx.responder.(PanicResponder); ok {
+		responder.HandlePanic(ctx, pe)
+	}
+	if !ctx.Writer.Committed() {
+		ctx.Writer.ClearBuffer()
+	}
+	msg := http.StatusText(http.StatusInternalServerError)
+	ctx.Errors.AddError(Error{Code: http.StatusInternalServerError, Message: msg, Object: pe, Cause: pe})
+	if ctx.responder != nil {
+		ctx.responder.HandleError(ctx, http.StatusInternalServerError, msg)
+	}
+	return pe
+}
+
+/*
+SetPanicHandler Method
+*/
+func (ehm *ErrorHandlerMixin) SetPanicHandler(h PanicHandler) *ErrorHandlerMixin {
+	ehm.panicHandler = h
+	return ehm
+}
+
+/*
+GetPanicHandler Method
+*/
+func (ehm *ErrorHandlerMixin) GetPanicHandler() PanicHandler {
+	return ehm.panicHandler
+}
+
+/*
+HandlePanic Method
+*/
+func (rg *Router) HandlePanic(ctx *Context, pe *PanicError) {
+	if handler := rg.GetPanicHandler(); handler != nil {
+		handler(ctx, pe)
+	}
+}
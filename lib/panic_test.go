@@ -0,0 +1,91 @@
+package lib
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+type panicValue struct {
+	code int
+}
+
+func TestPanicRecovery(t *testing.T) {
+	boom := errors.New("boom")
+	values := []interface{}{boom, 42, panicValue{7}}
+	for _, timeout := range []time.Duration{0, time.Second} {
+		for _, value := range values {
+			handled := 0
+			rt := PlainRouter()
+			rt.SetPanicHandler(func(ctx *Context, pe *PanicError) {
+				handled++
+			})
+			rt.GET("/panic", func(ctx *Context) {
+				ctx.WriteString("partial")
+				panic(value)
+			}).Timeout(timeout)
+
+			rec := httptest.NewRecorder()
+			ctx := AcquireContext(rec, httptest.NewRequest(http.MethodGet, "/panic", nil))
+			ctx.SetResponder(rt)
+			rt.Handle(ctx)
+
+			if rec.Code != http.StatusInternalServerError {
+				t.Errorf("%v/%v: expected 500, got %d", timeout, value, rec.Code)
+			}
+			if strings.Contains(rec.Body.String(), "partial") || strings.Contains(rec.Body.String(), "boom") {
+				t.Errorf("%v/%v: expected no partial output or panic value, got %q", timeout, value, rec.Body.String())
+			}
+			if handled != 1 {
+				t.Errorf("%v/%v: expected panic handler to run once, ran %d times", timeout, value, handled)
+			}
+			last := ctx.Errors.Last()
+			if last == nil {
+				t.Fatalf("%v/%v: expected the panic to be recorded", timeout, value)
+			}
+			pe, ok := last.Object.(*PanicError)
+			if !ok || pe.Value != value || len(pe.Stack) < 1 {
+				t.Errorf("%v/%v: expected *PanicError with a stack, got %#v", timeout, value, last.Object)
+			}
+			if last.Message != http.StatusText(http.StatusInternalServerError) {
+				t.Errorf("%v/%v: expected generic message, got %q", timeout, value, last.Message)
+			}
+			if err, isErr := value.(error); isErr && !errors.Is(ctx.Errors, err) {
+				t.Errorf("%v/%v: expected errors to wrap the panic value", timeout, value)
+			}
+			ReleaseContext(ctx)
+		}
+	}
+}
+
+func TestPrettyErrorsLogsPanicStack(t *testing.T) {
+	rt := PlainRouter()
+	rt.AddMiddleware(PrettyErrorsMiddleware)
+	rt.GET("/panic", func(ctx *Context) {
+		panic(errors.New("boom"))
+	})
+
+	stdout := os.Stdout
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = writer
+	rec := httptest.NewRecorder()
+	rt.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/panic", nil))
+	os.Stdout = stdout
+	writer.Close()
+	output, _ := io.ReadAll(reader)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500, got %d", rec.Code)
+	}
+	if !strings.Contains(string(output), "panic: boom") || !strings.Contains(string(output), "goroutine") {
+		t.Errorf("expected cause and stack in log, got %q", output)
+	}
+}
@@ -103,6 +103,17 @@ func (params *Params) Copy(p2 Params) {
 	}
 }
 
+/*
+Clone Method
+*/
+func (params *Params) Clone() *Params {
+	cloned := make(Params, 0, len(*params))
+	for _, p := range *params {
+		cloned.AddParam(&Param{key: p.key, placeholder: p.placeholder, value: p.value})
+	}
+	return &cloned
+}
+
 /*
 Size Method
 */
@@ -1,7 +1,7 @@
 package lib
 
 import (
-	"net/http"
+	"fmt"
 )
 
 /*
@@ -25,6 +25,7 @@ type Pipeline struct {
 	cbError  PipelineErrorCallback
 	ctx      *Context
 	queue    []Middleware
+	nextCb   PipelineCallback
 }
 
 /*
@@ -48,7 +49,10 @@ Reset Method
 */
 func (pl *Pipeline) Reset() {
 	pl.ClearCallbacks()
-	pl.queue = []Middleware{}
+	for i := range pl.queue {
+		pl.queue[i] = nil
+	}
+	pl.queue = pl.queue[:0]
 }
 
 /*
@@ -113,20 +117,24 @@ func (pl *Pipeline) OnError(cb PipelineErrorCallback) {
 }
 
 func (pl *Pipeline) next() {
+	if pl.ctx != nil && pl.ctx.Err() != nil {
+		pl.stop()
+		return
+	}
 	newPosition := pl.position + 1
 	if (len(pl.queue) - 1) >= newPosition {
 		pl.position = newPosition
 		defer func() {
 			if r := recover(); r != nil {
 				if pl.cbError != nil {
-					pl.cbError(r.(string), pl.next, pl.stop)
+					pl.cbError(fmt.Sprint(r), pl.nextCb, pl.stop)
 				} else {
-					pl.ctx.Error(http.StatusInternalServerError, r.(string))
-					pl.stopOnError(r.(string))
+					pe := pl.ctx.Recover(r)
+					pl.stopOnError(pe.Error())
 				}
 			}
 		}()
-		pl.queue[newPosition](pl.ctx, pl.next)
+		pl.queue[newPosition](pl.ctx, pl.nextCb)
 	} else {
 		pl.done = true
 		if pl.cbAfter != nil {
@@ -150,6 +158,7 @@ NewPipeline Function
 */
 func NewPipeline(ctx *Context) *Pipeline {
 	pipeline := &Pipeline{ctx: ctx}
+	pipeline.nextCb = pipeline.next
 	pipeline.Reset()
 	return pipeline
 }
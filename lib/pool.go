@@ -0,0 +1,81 @@
+package lib
+
+import (
+	"net/http"
+	"sync"
+)
+
+var contextPool = sync.Pool{
+	New: func() interface{} {
+		return &Context{}
+	},
+}
+
+var pipelinePool = sync.Pool{
+	New: func() interface{} {
+		return NewPipeline(nil)
+	},
+}
+
+/*
+AcquireContext Function
+*/
+func AcquireContext(w http.ResponseWriter, r *http.Request) *Context {
+	ctx := contextPool.Get().(*Context)
+	ctx.reset(w, r)
+	return ctx
+}
+
+/*
+ReleaseContext Function
+*/
+func ReleaseContext(ctx *Context) {
+	if ctx == nil || ctx.released || ctx.detached || len(ctx.finalizers) > 0 {
+		return
+	}
+	ctx.released = true
+	ctx.Writer.Reset()
+	ctx.Writer.closed = true
+	ctx.recorder.writer = nil
+	ctx.IOWriter = nil
+	ctx.Request = nil
+	ctx.Route = nil
+	ctx.responder = nil
+	ctx.proxies = nil
+	ctx.buffered = nil
+	ctx.pathMatcher = nil
+	ctx.pathValues = nil
+	for i := range ctx.finalizers {
+		ctx.finalizers[i] = nil
+	}
+	ctx.finalizers = ctx.finalizers[:0]
+	ctx.values.Clear()
+	contextPool.Put(ctx)
+}
+
+/*
+Detach Method
+*/
+func (ctx *Context) Detach() *Context {
+	ctx.detached = true
+	return ctx
+}
+
+/*
+Released Method
+*/
+func (ctx *Context) Released() bool {
+	return ctx.released
+}
+
+func acquirePipeline(ctx *Context) *Pipeline {
+	pipeline := pipelinePool.Get().(*Pipeline)
+	pipeline.ctx = ctx
+	return pipeline
+}
+
+func releasePipeline(pipeline *Pipeline) {
+	pipeline.Reset()
+	pipeline.ctx = nil
+	pipelinePool.Put(pipeline)
+}
@@ -0,0 +1,94 @@
+package lib
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestReleasedContextIsClean(t *testing.T) {
+	ctx := AcquireContext(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/user/42", nil))
+	ctx.Writer.Header("X-Test", "1")
+	ctx.Write([]byte("body"))
+	ctx.Params.Add("id", ":id")
+	ctx.Set("key", "value")
+	ctx.Errors.Add("failed", http.StatusBadRequest, nil)
+	ctx.OnFinalize(func() {})
+	ctx.Finalize()
+	ReleaseContext(ctx)
+
+	if !ctx.Released() {
+		t.Fatal("expected context to be released")
+	}
+	if len(ctx.Writer.Bytes()) > 0 {
+		t.Errorf("expected empty buffer, got %q", ctx.Writer.Bytes())
+	}
+	if len(ctx.Writer.Headers) > 0 {
+		t.Errorf("expected empty headers, got %v", ctx.Writer.Headers)
+	}
+	if ctx.Values().Has("key") {
+		t.Error("expected values to be cleared")
+	}
+	if len(ctx.finalizers) > 0 {
+		t.Error("expected finalizers to be cleared")
+	}
+	if ctx.Request != nil || ctx.IOWriter != nil {
+		t.Error("expected request and response writer to be dropped")
+	}
+
+	ctx.reset(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	if ctx.Params.Size() > 0 || !ctx.Errors.Empty() || ctx.Writer.Closed() || ctx.Writer.StatusCode != 0 {
+		t.Error("expected reset context to start clean")
+	}
+}
+
+func TestWriteAfterFinalize(t *testing.T) {
+	ctx := NewContext(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	ctx.Finalize()
+	if _, err := ctx.Writer.Write([]byte("late")); !errors.Is(err, ErrWriterClosed) {
+		t.Errorf("expected ErrWriterClosed, got %v", err)
+	}
+
+	ctx = AcquireContext(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	ctx.Finalize()
+	ReleaseContext(ctx)
+	if _, err := ctx.Writer.WriteString("released"); !errors.Is(err, ErrWriterClosed) {
+		t.Errorf("expected ErrWriterClosed after release, got %v", err)
+	}
+	if len(ctx.Writer.Bytes()) > 0 {
+		t.Errorf("expected released buffer to stay empty, got %q", ctx.Writer.Bytes())
+	}
+}
+
+func TestReleaseWithEventStreamHeartbeat(t *testing.T) {
+	rt := PlainRouter()
+	rt.GET("/events", func(ctx *Context) {
+		es := ctx.SSE()
+		es.Heartbeat(100 * time.Microsecond)
+		time.Sleep(2 * time.Millisecond)
+	})
+	rt.GET("/plain", func(ctx *Context) {
+		ctx.Write([]byte("plain"))
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 200; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			rt.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/events", nil))
+		}()
+		go func() {
+			defer wg.Done()
+			w := httptest.NewRecorder()
+			rt.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/plain", nil))
+			if w.Body.String() != "plain" {
+				t.Errorf("expected plain body, got %q", w.Body.String())
+			}
+		}()
+	}
+	wg.Wait()
+}
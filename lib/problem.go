@@ -0,0 +1,272 @@
+package lib
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"net/http"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*
+ProblemNamespace Constant
+*/
+const ProblemNamespace = "urn:ietf:rfc:7807"
+
+/*
+Problem Object
+*/
+type Problem struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Extensions map[string]interface{}
+}
+
+/*
+MarshalJSON Method
+*/
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	doc := map[string]interface{}{}
+	for key, val := range p.Extensions {
+		doc[key] = val
+	}
+	doc["type"] = p.Type
+	doc["title"] = p.Title
+	doc["status"] = p.Status
+	if len(p.Detail) > 0 {
+		doc["detail"] = p.Detail
+	}
+	if len(p.Instance) > 0 {
+		doc["instance"] = p.Instance
+	}
+	return json.Marshal(doc)
+}
+
+/*
+MarshalXML Method
+*/
+func (p *Problem) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name = xml.Name{Local: "problem"}
+	start.Attr = []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: ProblemNamespace}}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	fields := [][2]string{
+		{"type", p.Type},
+		{"title", p.Title},
+		{"status", strconv.Itoa(p.Status)},
+		{"detail", p.Detail},
+		{"instance", p.Instance},
+	}
+	for _, field := range fields {
+		if len(field[1]) < 1 {
+			continue
+		}
+		if err := e.EncodeElement(field[1], xml.StartElement{Name: xml.Name{Local: field[0]}}); err != nil {
+			return err
+		}
+	}
+	if err := encodeProblemMembers(e, p.Extensions); err != nil {
+		return err
+	}
+	return e.EncodeToken(start.End())
+}
+
+func encodeProblemMembers(e *xml.Encoder, members map[string]interface{}) error {
+	keys := make([]string, 0, len(members))
+	for key := range members {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := encodeProblemValue(e, key, members[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var xmlNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9._-]*$`)
+
+func problemElement(name string) xml.StartElement {
+	if xmlNameRegexp.MatchString(name) && !strings.HasPrefix(strings.ToLower(name), "xml") {
+		return xml.StartElement{Name: xml.Name{Local: name}}
+	}
+	return xml.StartElement{
+		Name: xml.Name{Local: "extension"},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "name"}, Value: name}},
+	}
+}
+
+func encodeProblemValue(e *xml.Encoder, name string, val interface{}) error {
+	start := problemElement(name)
+	switch v := val.(type) {
+	case []interface{}:
+		if err := e.EncodeToken(start); err != nil {
+			return err
+		}
+		for _, item := range v {
+			if err := encodeProblemValue(e, "i", item); err != nil {
+				return err
+			}
+		}
+		return e.EncodeToken(start.End())
+	case map[string]interface{}:
+		if err := e.EncodeToken(start); err != nil {
+			return err
+		}
+		if err := encodeProblemMembers(e, v); err != nil {
+			return err
+		}
+		return e.EncodeToken(start.End())
+	case nil:
+		return e.EncodeElement("", start)
+	}
+	return e.EncodeElement(fmt.Sprint(val), start)
+}
+
+/*
+HTML Method
+*/
+func (p *Problem) HTML() string {
+	body := fmt.Sprintf("<h1>%d %s</h1>\n", p.Status, html.EscapeString(p.Title))
+	if len(p.Detail) > 0 {
+		body += fmt.Sprintf("<p>%s</p>\n", html.EscapeString(p.Detail))
+	}
+	return fmt.Sprintf("<!DOCTYPE html>\n<html>\n<head><title>%d %s</title></head>\n<body>\n%s</body>\n</html>\n", p.Status, html.EscapeString(p.Title), body)
+}
+
+/*
+ProblemResponder Object
+*/
+type ProblemResponder struct {
+	TypeBase string
+}
+
+/*
+Problem Method
+*/
+func (pr *ProblemResponder) Problem(ctx *Context, status int, msg string) *Problem {
+	problem := &Problem{
+		Type:       "about:blank",
+		Title:      http.StatusText(status),
+		Status:     status,
+		Detail:     msg,
+		Instance:   ctx.Path,
+		Extensions: map[string]interface{}{},
+	}
+	if len(pr.TypeBase) > 0 {
+		problem.Type = strings.TrimSuffix(pr.TypeBase, "/") + "/" + strconv.Itoa(status)
+	}
+	if problem.Detail == problem.Title {
+		problem.Detail = ""
+	}
+	if err := ctx.Errors.Last(); err != nil && err.Code == status {
+		problemExtensions(problem.Extensions, err.Object)
+	}
+	return problem
+}
+
+/*
+HandleError Method
+*/
+func (pr *ProblemResponder) HandleError(ctx *Context, status int, msg string) {
+	problem := pr.Problem(ctx, status, msg)
+	ctx.Status(status)
+	if !ctx.Writer.Committed() {
+		ctx.Writer.ClearBuffer()
+	}
+	var body []byte
+	var err error
+	switch NegotiateProblemFormat(ctx) {
+	case "json":
+		ctx.Writer.Header("Content-Type", "application/problem+json")
+		body, err = json.Marshal(problem)
+	case "xml":
+		ctx.Writer.Header("Content-Type", "application/problem+xml")
+		body, err = xml.Marshal(problem)
+		body = append([]byte(xml.Header), body...)
+	default:
+		ctx.Writer.Header("Content-Type", "text/html; charset=utf-8")
+		body = []byte(problem.HTML())
+	}
+	if err != nil {
+		ctx.Writer.Header("Content-Type", "text/plain; charset=utf-8")
+		body = []byte(fmt.Sprintf("%d %s", status, problem.Title))
+	}
+	ctx.Write(body)
+}
+
+/*
+NewProblemResponder Function
+*/
+func NewProblemResponder(typeBase string) *ProblemResponder {
+	return &ProblemResponder{TypeBase: typeBase}
+}
+
+/*
+NegotiateProblemFormat Function
+*/
+func NegotiateProblemFormat(ctx *Context) string {
+	switch strings.ToLower(path.Ext(ctx.Path)) {
+	case ".json":
+		return "json"
+	case ".xml":
+		return "xml"
+	case ".html", ".htm":
+		return "html"
+	}
+	for _, item := range ParseQualityList(ctx.Request.Header.Get("Accept")) {
+		if item.Quality <= 0 {
+			continue
+		}
+		switch {
+		case strings.Contains(item.Value, "html"):
+			return "html"
+		case strings.Contains(item.Value, "json"):
+			return "json"
+		case strings.Contains(item.Value, "xml"):
+			return "xml"
+		case item.Value == "*/*" || item.Value == "text/*":
+			return "html"
+		}
+	}
+	return "html"
+}
+
+func problemExtensions(ext map[string]interface{}, obj interface{}) {
+	if obj == nil {
+		return
+	}
+	if _, ok := obj.(error); ok {
+		return
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return
+	}
+	var decoded interface{}
+	if json.Unmarshal(data, &decoded) != nil {
+		return
+	}
+	members, ok := decoded.(map[string]interface{})
+	if !ok {
+		ext["details"] = decoded
+		return
+	}
+	for key, val := range members {
+		switch key {
+		case "type", "title", "status", "detail", "instance":
+			continue
+		}
+		ext[key] = val
+	}
+}
@@ -0,0 +1,45 @@
+package lib
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestProblemXMLExtensionNames(t *testing.T) {
+	rt := PlainRouter()
+	rt.SetErrorResponder(NewProblemResponder(""))
+	rt.GET("/bad", func(ctx *Context) {
+		ctx.DetailedError(http.StatusUnprocessableEntity, "invalid", map[string]interface{}{"bad key": 1, "1st": 2, "ok": 3})
+	})
+	r := httptest.NewRequest(http.MethodGet, "/bad", nil)
+	r.Header.Set("Accept", "application/xml")
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, r)
+
+	decoder := xml.NewDecoder(strings.NewReader(w.Body.String()))
+	for {
+		_, err := decoder.Token()
+		if err != nil {
+			if err != io.EOF {
+				t.Fatalf("malformed problem XML %q: %v", w.Body.String(), err)
+			}
+			break
+		}
+	}
+	if !strings.Contains(w.Body.String(), `<extension name="bad key">1</extension>`) {
+		t.Errorf("expected invalid names to be nested under extension, got %q", w.Body.String())
+	}
+}
+
+func TestPlainErrorsByDefault(t *testing.T) {
+	rt := PlainRouter()
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	if body := w.Body.String(); body != "Error #404 => Page not found" {
+		t.Errorf("expected plain text error, got %q", body)
+	}
+}
@@ -0,0 +1,148 @@
+package lib
+
+import (
+	"net"
+	"strings"
+)
+
+/*
+TrustedProxies Object
+*/
+type TrustedProxies []*net.IPNet
+
+/*
+Add Method
+*/
+func (tp *TrustedProxies) Add(cidr string) bool {
+	network := ParseCIDR(cidr)
+	if network == nil {
+		return false
+	}
+	*tp = append(*tp, network)
+	return true
+}
+
+/*
+Copy Method
+*/
+func (tp *TrustedProxies) Copy(tp2 TrustedProxies) {
+	*tp = append(*tp, tp2...)
+}
+
+/*
+Size Method
+*/
+func (tp *TrustedProxies) Size() int {
+	return len(*tp)
+}
+
+/*
+Contains Method
+*/
+func (tp *TrustedProxies) Contains(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, network := range *tp {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+ClientIP Method
+*/
+func (tp *TrustedProxies) ClientIP(remoteAddr string, forwardedFor []string) string {
+	remote := RemoteHost(remoteAddr)
+	if !tp.Contains(net.ParseIP(remote)) {
+		return remote
+	}
+	hops := []string{}
+	for _, header := range forwardedFor {
+		for _, hop := range strings.Split(header, ",") {
+			hops = append(hops, strings.TrimSpace(hop))
+		}
+	}
+	client := remote
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.Trim(RemoteHost(hops[i]), "[]"))
+		if ip == nil {
+			break
+		}
+		client = ip.String()
+		if !tp.Contains(ip) {
+			break
+		}
+	}
+	return client
+}
+
+/*
+ProxyMixin Object
+*/
+type ProxyMixin struct {
+	proxies *TrustedProxies
+}
+
+func (pxm *ProxyMixin) initProxies() {
+	pxm.proxies = &TrustedProxies{}
+}
+
+/*
+TrustProxy Method
+*/
+func (pxm *ProxyMixin) TrustProxy(cidrs ...string) *ProxyMixin {
+	for _, cidr := range cidrs {
+		pxm.proxies.Add(cidr)
+	}
+	return pxm
+}
+
+/*
+TrustedProxies Method
+*/
+func (pxm *ProxyMixin) TrustedProxies() *TrustedProxies {
+	return pxm.proxies
+}
+
+/*
+CopyProxies Method
+*/
+func (pxm *ProxyMixin) CopyProxies(pxm2 ProxyMixin) {
+	pxm.proxies.Copy(*pxm2.TrustedProxies())
+}
+
+/*
+ParseCIDR Function
+*/
+func ParseCIDR(cidr string) *net.IPNet {
+	cidr = strings.TrimSpace(cidr)
+	if !strings.Contains(cidr, "/") {
+		ip := net.ParseIP(cidr)
+		if ip == nil {
+			return nil
+		}
+		if ip.To4() != nil {
+			return &net.IPNet{IP: ip.To4(), Mask: net.CIDRMask(32, 32)}
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}
+	}
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil
+	}
+	return network
+}
+
+/*
+RemoteHost Function
+*/
+func RemoteHost(remoteAddr string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	return host
+}
@@ -0,0 +1,35 @@
+package lib
+
+import (
+	"testing"
+)
+
+func TestTrustedProxiesClientIP(t *testing.T) {
+	proxies := &TrustedProxies{}
+	proxies.Add("10.0.0.0/8")
+	proxies.Add("fd00::/8")
+
+	cases := []struct {
+		name   string
+		remote string
+		xff    []string
+		want   string
+	}{
+		{"untrusted remote ignores header", "203.0.113.7:5000", []string{"1.2.3.4"}, "203.0.113.7"},
+		{"trusted remote without header", "10.0.0.1:5000", nil, "10.0.0.1"},
+		{"single trusted hop", "10.0.0.1:5000", []string{"198.51.100.2"}, "198.51.100.2"},
+		{"chain of trusted hops", "10.0.0.1:5000", []string{"198.51.100.2, 10.0.0.3", "10.0.0.2"}, "198.51.100.2"},
+		{"spoofed left part is skipped", "10.0.0.1:5000", []string{"6.6.6.6, 198.51.100.2, 10.0.0.2"}, "198.51.100.2"},
+		{"all hops trusted", "10.0.0.1:5000", []string{"10.0.0.5, 10.0.0.2"}, "10.0.0.5"},
+		{"garbage hop stops the walk", "10.0.0.1:5000", []string{"garbage, 10.0.0.2"}, "10.0.0.2"},
+		{"garbage nearest hop", "10.0.0.1:5000", []string{"198.51.100.2, garbage"}, "10.0.0.1"},
+		{"ipv6 remote and hops", "[fd00::1]:443", []string{"2001:db8::5, fd00::2"}, "2001:db8::5"},
+		{"ipv6 bracketed hop with port", "[fd00::1]:443", []string{"[2001:db8::6]:1234"}, "2001:db8::6"},
+		{"untrusted ipv6 remote", "[2001:db8::9]:443", []string{"1.2.3.4"}, "2001:db8::9"},
+	}
+	for _, tc := range cases {
+		if got := proxies.ClientIP(tc.remote, tc.xff); got != tc.want {
+			t.Errorf("%s: got %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
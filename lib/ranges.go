@@ -0,0 +1,175 @@
+package lib
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+ErrRangeNotSatisfiable Variable
+*/
+var ErrRangeNotSatisfiable = errors.New("range not satisfiable")
+
+/*
+ByteRange Object
+*/
+type ByteRange struct {
+	Start  int64
+	Length int64
+}
+
+/*
+ContentRange Method
+*/
+func (br ByteRange) ContentRange(size int64) string {
+	return fmt.Sprintf("bytes %d-%d/%d", br.Start, br.Start+br.Length-1, size)
+}
+
+/*
+ParseByteRanges Function
+*/
+func ParseByteRanges(header string, size int64) ([]ByteRange, error) {
+	if !strings.HasPrefix(header, "bytes=") {
+		return nil, errors.New("invalid range unit")
+	}
+	ranges := []ByteRange{}
+	satisfiable := false
+	for _, spec := range strings.Split(header[len("bytes="):], ",") {
+		spec = strings.TrimSpace(spec)
+		if len(spec) < 1 {
+			continue
+		}
+		i := strings.Index(spec, "-")
+		if i == -1 {
+			return nil, errors.New("invalid range")
+		}
+		first, last := strings.TrimSpace(spec[:i]), strings.TrimSpace(spec[i+1:])
+		br := ByteRange{}
+		if len(first) < 1 {
+			suffix, err := strconv.ParseInt(last, 10, 64)
+			if err != nil || suffix < 0 {
+				return nil, errors.New("invalid range")
+			}
+			if suffix == 0 {
+				continue
+			}
+			if suffix > size {
+				suffix = size
+			}
+			br.Start = size - suffix
+			br.Length = suffix
+		} else {
+			start, err := strconv.ParseInt(first, 10, 64)
+			if err != nil || start < 0 {
+				return nil, errors.New("invalid range")
+			}
+			if start >= size {
+				continue
+			}
+			br.Start = start
+			br.Length = size - start
+			if len(last) > 0 {
+				end, err := strconv.ParseInt(last, 10, 64)
+				if err != nil || end < start {
+					return nil, errors.New("invalid range")
+				}
+				if end < size-1 {
+					br.Length = end - start + 1
+				}
+			}
+		}
+		if br.Length < 1 {
+			continue
+		}
+		ranges = append(ranges, br)
+		satisfiable = true
+	}
+	if !satisfiable {
+		return nil, ErrRangeNotSatisfiable
+	}
+	return ranges, nil
+}
+
+func (ctx *Context) applyRange() {
+	w := ctx.Writer
+	if w.status() != http.StatusOK || (ctx.Method != http.MethodGet && ctx.Method != http.MethodHead) {
+		return
+	}
+	if w.GetHeader("Accept-Ranges") == "none" || len(w.GetHeader("Content-Encoding")) > 0 {
+		return
+	}
+	w.Header("Accept-Ranges", "bytes")
+	header := ctx.Request.Header.Get("Range")
+	if ctx.Method != http.MethodGet || len(header) < 1 || !ctx.checkIfRange() {
+		return
+	}
+	body := w.Bytes()
+	size := int64(len(body))
+	ranges, err := ParseByteRanges(header, size)
+	if err == ErrRangeNotSatisfiable {
+		w.Status(http.StatusRequestedRangeNotSatisfiable)
+		w.Header("Content-Range", fmt.Sprintf("bytes */%d", size))
+		w.DelHeader("Content-Length")
+		w.ClearBuffer()
+		return
+	}
+	if err != nil || len(ranges) < 1 {
+		return
+	}
+	var total int64
+	for _, br := range ranges {
+		total += br.Length
+	}
+	if total > size {
+		return
+	}
+	w.DelHeader("Content-Length")
+	if len(ranges) == 1 {
+		part := append([]byte(nil), body[ranges[0].Start:ranges[0].Start+ranges[0].Length]...)
+		w.Status(http.StatusPartialContent)
+		w.Header("Content-Range", ranges[0].ContentRange(size))
+		w.ClearBuffer()
+		w.Write(part)
+		return
+	}
+	var multi bytes.Buffer
+	mw := multipart.NewWriter(&multi)
+	contentType := w.GetHeader("Content-Type")
+	for _, br := range ranges {
+		partHeader := textproto.MIMEHeader{}
+		if len(contentType) > 0 {
+			partHeader.Set("Content-Type", contentType)
+		}
+		partHeader.Set("Content-Range", br.ContentRange(size))
+		part, _ := mw.CreatePart(partHeader)
+		part.Write(body[br.Start : br.Start+br.Length])
+	}
+	mw.Close()
+	w.Status(http.StatusPartialContent)
+	w.Header("Content-Type", "multipart/byteranges; boundary="+mw.Boundary())
+	w.ClearBuffer()
+	w.Write(multi.Bytes())
+}
+
+func (ctx *Context) checkIfRange() bool {
+	ifRange := strings.TrimSpace(ctx.Request.Header.Get("If-Range"))
+	if len(ifRange) < 1 {
+		return true
+	}
+	if strings.HasPrefix(ifRange, "\"") || strings.HasPrefix(ifRange, "W/") {
+		return ETagMatch(ifRange, ctx.Writer.GetHeader("ETag"), false)
+	}
+	since, err := http.ParseTime(ifRange)
+	if err != nil {
+		return false
+	}
+	modified, err := http.ParseTime(ctx.Writer.GetHeader("Last-Modified"))
+	return err == nil && modified.Truncate(time.Second).Equal(since)
+}
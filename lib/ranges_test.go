@@ -0,0 +1,142 @@
+package lib
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestParseByteRanges(t *testing.T) {
+	cases := []struct {
+		header string
+		want   []ByteRange
+		err    bool
+	}{
+		{"bytes=0-4", []ByteRange{{0, 5}}, false},
+		{"bytes=5-", []ByteRange{{5, 5}}, false},
+		{"bytes=-3", []ByteRange{{7, 3}}, false},
+		{"bytes=-20", []ByteRange{{0, 10}}, false},
+		{"bytes=8-20", []ByteRange{{8, 2}}, false},
+		{"bytes=0-1, 4-5", []ByteRange{{0, 2}, {4, 2}}, false},
+		{"bytes=20-30, 2-3", []ByteRange{{2, 2}}, false},
+		{"bytes=20-30", nil, true},
+		{"bytes=-0", nil, true},
+		{"bytes=5-2", nil, true},
+		{"bytes=abc", nil, true},
+		{"items=0-1", nil, true},
+	}
+	for _, tc := range cases {
+		got, err := ParseByteRanges(tc.header, 10)
+		if (err != nil) != tc.err {
+			t.Errorf("%q: unexpected error %v", tc.header, err)
+			continue
+		}
+		if !tc.err && !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%q: got %v, want %v", tc.header, got, tc.want)
+		}
+	}
+	if _, err := ParseByteRanges("bytes=20-", 10); err != ErrRangeNotSatisfiable {
+		t.Errorf("expected ErrRangeNotSatisfiable, got %v", err)
+	}
+}
+
+func rangeRouter() *Router {
+	rt := PlainRouter()
+	rt.GET("/", func(ctx *Context) {
+		ctx.Writer.Header("Content-Type", "text/plain")
+		ctx.Writer.Header("ETag", "\"v1\"")
+		ctx.Writer.Header("Last-Modified", "Tue, 02 Jan 2024 03:04:05 GMT")
+		ctx.WriteString("0123456789")
+	})
+	rt.HEAD("/", func(ctx *Context) {
+		ctx.Writer.Header("Content-Type", "text/plain")
+		ctx.WriteString("0123456789")
+	})
+	rt.GET("/encoded", func(ctx *Context) {
+		ctx.Writer.Header("Content-Encoding", "gzip")
+		ctx.WriteString("0123456789")
+	})
+	return rt
+}
+
+func rangeRequest(rt *Router, method string, path string, headers map[string]string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, path, nil)
+	for key, val := range headers {
+		r.Header.Set(key, val)
+	}
+	rec := httptest.NewRecorder()
+	rt.ServeHTTP(rec, r)
+	return rec
+}
+
+func TestRangeResponses(t *testing.T) {
+	rt := rangeRouter()
+	cases := []struct {
+		name         string
+		method       string
+		path         string
+		headers      map[string]string
+		status       int
+		body         string
+		contentRange string
+	}{
+		{"single range", http.MethodGet, "/", map[string]string{"Range": "bytes=2-4"}, http.StatusPartialContent, "234", "bytes 2-4/10"},
+		{"unsatisfiable", http.MethodGet, "/", map[string]string{"Range": "bytes=50-"}, http.StatusRequestedRangeNotSatisfiable, "", "bytes */10"},
+		{"if-range etag match", http.MethodGet, "/", map[string]string{"Range": "bytes=-2", "If-Range": "\"v1\""}, http.StatusPartialContent, "89", "bytes 8-9/10"},
+		{"if-range etag mismatch", http.MethodGet, "/", map[string]string{"Range": "bytes=-2", "If-Range": "\"v0\""}, http.StatusOK, "0123456789", ""},
+		{"if-range weak etag", http.MethodGet, "/", map[string]string{"Range": "bytes=-2", "If-Range": "W/\"v1\""}, http.StatusOK, "0123456789", ""},
+		{"if-range date match", http.MethodGet, "/", map[string]string{"Range": "bytes=0-0", "If-Range": "Tue, 02 Jan 2024 03:04:05 GMT"}, http.StatusPartialContent, "0", "bytes 0-0/10"},
+		{"if-range date mismatch", http.MethodGet, "/", map[string]string{"Range": "bytes=0-0", "If-Range": "Mon, 01 Jan 2024 00:00:00 GMT"}, http.StatusOK, "0123456789", ""},
+		{"head ignores range", http.MethodHead, "/", map[string]string{"Range": "bytes=2-4"}, http.StatusOK, "", ""},
+		{"encoded ignores range", http.MethodGet, "/encoded", map[string]string{"Range": "bytes=2-4"}, http.StatusOK, "0123456789", ""},
+	}
+	for _, tc := range cases {
+		rec := rangeRequest(rt, tc.method, tc.path, tc.headers)
+		if rec.Code != tc.status || rec.Body.String() != tc.body {
+			t.Errorf("%s: got %d %q, want %d %q", tc.name, rec.Code, rec.Body.String(), tc.status, tc.body)
+		}
+		if got := rec.Header().Get("Content-Range"); got != tc.contentRange {
+			t.Errorf("%s: got Content-Range %q, want %q", tc.name, got, tc.contentRange)
+		}
+	}
+
+	if rec := rangeRequest(rt, http.MethodGet, "/", nil); rec.Header().Get("Accept-Ranges") != "bytes" {
+		t.Error("expected Accept-Ranges: bytes")
+	}
+	if rec := rangeRequest(rt, http.MethodGet, "/encoded", nil); len(rec.Header().Get("Accept-Ranges")) > 0 {
+		t.Error("expected no Accept-Ranges for encoded response")
+	}
+}
+
+func TestMultipartRanges(t *testing.T) {
+	rec := rangeRequest(rangeRouter(), http.MethodGet, "/", map[string]string{"Range": "bytes=0-1, 7-"})
+	if rec.Code != http.StatusPartialContent {
+		t.Fatalf("expected 206, got %d", rec.Code)
+	}
+	mediaType, params, err := mime.ParseMediaType(rec.Header().Get("Content-Type"))
+	if err != nil || mediaType != "multipart/byteranges" {
+		t.Fatalf("expected multipart/byteranges, got %q", rec.Header().Get("Content-Type"))
+	}
+	reader := multipart.NewReader(rec.Body, params["boundary"])
+	want := []struct{ contentRange, body string }{{"bytes 0-1/10", "01"}, {"bytes 7-9/10", "789"}}
+	for _, expected := range want {
+		part, err := reader.NextPart()
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(part)
+		if part.Header.Get("Content-Range") != expected.contentRange || string(body) != expected.body {
+			t.Errorf("got part %q %q, want %q %q", part.Header.Get("Content-Range"), body, expected.contentRange, expected.body)
+		}
+		if part.Header.Get("Content-Type") != "text/plain" {
+			t.Errorf("expected part Content-Type text/plain, got %q", part.Header.Get("Content-Type"))
+		}
+	}
+	if _, err := reader.NextPart(); err != io.EOF {
+		t.Errorf("expected two parts, got %v", err)
+	}
+}
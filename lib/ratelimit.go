@@ -0,0 +1,209 @@
+package lib
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+/*
+RateLimitKeyFunc Function
+*/
+type RateLimitKeyFunc func(*Context) string
+
+/*
+RateLimitResult Object
+*/
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+/*
+RateLimitStore Interface
+*/
+type RateLimitStore interface {
+	Take(key string, burst int, rate float64) (RateLimitResult, error)
+}
+
+/*
+RateLimitConfig Object
+*/
+type RateLimitConfig struct {
+	Limit  int
+	Period time.Duration
+	Burst  int
+	Key    RateLimitKeyFunc
+	Store  RateLimitStore
+}
+
+/*
+MakeRateLimitMiddleware Function
+*/
+func MakeRateLimitMiddleware(config RateLimitConfig) Middleware {
+	if config.Limit < 1 {
+		config.Limit = 1
+	}
+	if config.Period <= 0 {
+		config.Period = time.Second
+	}
+	if config.Burst < 1 {
+		config.Burst = config.Limit
+	}
+	if config.Key == nil {
+		config.Key = RateLimitByClientIP
+	}
+	if config.Store == nil {
+		config.Store = NewMemoryRateLimitStore()
+	}
+	rate := float64(config.Limit) / config.Period.Seconds()
+	policy := fmt.Sprintf("%d;w=%d", config.Limit, int(math.Ceil(config.Period.Seconds())))
+	return func(ctx *Context, next PipelineCallback) {
+		key := config.Key(ctx)
+		if len(key) < 1 {
+			next()
+			return
+		}
+		result, err := config.Store.Take(key, config.Burst, rate)
+		if err != nil {
+			next()
+			return
+		}
+		ctx.Writer.Header("RateLimit-Policy", policy)
+		ctx.Writer.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+		ctx.Writer.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		ctx.Writer.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+		if !result.Allowed {
+			ctx.Writer.Header("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			ctx.Error(http.StatusTooManyRequests, "Too many requests")
+			return
+		}
+		next()
+	}
+}
+
+/*
+RateLimitByClientIP Function
+*/
+func RateLimitByClientIP(ctx *Context) string {
+	return ctx.ClientIP()
+}
+
+/*
+RateLimitByHeader Function
+*/
+func RateLimitByHeader(name string) RateLimitKeyFunc {
+	return func(ctx *Context) string {
+		return ctx.Request.Header.Get(name)
+	}
+}
+
+/*
+RateLimitByRoute Function
+*/
+func RateLimitByRoute(ctx *Context) string {
+	if ctx.Route == nil {
+		return ""
+	}
+	if name := ctx.Route.GetName(); len(name) > 0 {
+		return name
+	}
+	return ctx.Route.path
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+/*
+MemoryRateLimitStore Object
+*/
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+/*
+Take Method
+*/
+func (ms *MemoryRateLimitStore) Take(key string, burst int, rate float64) (RateLimitResult, error) {
+	now := time.Now()
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.sweep(now, burst, rate)
+
+	bucket, found := ms.buckets[key]
+	if !found {
+		bucket = &tokenBucket{tokens: float64(burst), last: now}
+		ms.buckets[key] = bucket
+	}
+	bucket.refill(now, burst, rate)
+
+	result := RateLimitResult{Limit: burst}
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = rateDuration(1-bucket.tokens, rate)
+	}
+	result.Remaining = int(bucket.tokens)
+	result.Reset = rateDuration(float64(burst)-bucket.tokens, rate)
+	return result, nil
+}
+
+/*
+Len Method
+*/
+func (ms *MemoryRateLimitStore) Len() int {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	return len(ms.buckets)
+}
+
+func (ms *MemoryRateLimitStore) sweep(now time.Time, burst int, rate float64) {
+	window := rateDuration(float64(burst), rate)
+	if now.Sub(ms.lastSweep) < window || now.Sub(ms.lastSweep) < time.Minute {
+		return
+	}
+	ms.lastSweep = now
+	for key, bucket := range ms.buckets {
+		if now.Sub(bucket.last) >= window {
+			delete(ms.buckets, key)
+		}
+	}
+}
+
+func (tb *tokenBucket) refill(now time.Time, burst int, rate float64) {
+	elapsed := now.Sub(tb.last)
+	tb.last = now
+	if elapsed <= 0 || rate <= 0 {
+		return
+	}
+	tb.tokens = math.Min(float64(burst), tb.tokens+elapsed.Seconds()*rate)
+}
+
+func rateDuration(tokens float64, rate float64) time.Duration {
+	if rate <= 0 {
+		return 0
+	}
+	return time.Duration(tokens / rate * float64(time.Second))
+}
+
+/*
+NewMemoryRateLimitStore Function
+*/
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{buckets: map[string]*tokenBucket{}, lastSweep: time.Now()}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
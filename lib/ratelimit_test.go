@@ -0,0 +1,104 @@
+package lib
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketRefill(t *testing.T) {
+	start := time.Now()
+	cases := []struct {
+		name    string
+		tokens  float64
+		elapsed time.Duration
+		rate    float64
+		want    float64
+	}{
+		{"half second at 2/s", 0, 500 * time.Millisecond, 2, 1},
+		{"fractional rate", 0, 3 * time.Second, 0.5, 1.5},
+		{"capped at burst", 1, time.Hour, 2, 5},
+		{"clock going backwards", 2, -time.Second, 2, 2},
+		{"zero rate", 2, time.Second, 0, 2},
+	}
+	for _, tc := range cases {
+		bucket := &tokenBucket{tokens: tc.tokens, last: start}
+		bucket.refill(start.Add(tc.elapsed), 5, tc.rate)
+		if math.Abs(bucket.tokens-tc.want) > 1e-9 {
+			t.Errorf("%s: got %v tokens, want %v", tc.name, bucket.tokens, tc.want)
+		}
+	}
+}
+
+func TestMemoryRateLimitStoreTake(t *testing.T) {
+	store := NewMemoryRateLimitStore()
+	for i, remaining := range []int{1, 0} {
+		result, _ := store.Take("client", 2, 1)
+		if !result.Allowed || result.Remaining != remaining || result.Limit != 2 {
+			t.Errorf("take %d: unexpected result %+v", i, result)
+		}
+	}
+	result, _ := store.Take("client", 2, 1)
+	if result.Allowed || ceilSeconds(result.RetryAfter) != 1 {
+		t.Errorf("expected denial with a one second retry, got %+v", result)
+	}
+	if other, _ := store.Take("other", 2, 1); !other.Allowed {
+		t.Error("expected keys to have separate buckets")
+	}
+
+	store.buckets["client"].last = store.buckets["client"].last.Add(-time.Second)
+	if result, _ := store.Take("client", 2, 1); !result.Allowed {
+		t.Errorf("expected a refilled token after one second, got %+v", result)
+	}
+}
+
+func TestRateLimitHeaders(t *testing.T) {
+	rt := PlainRouter()
+	rt.AddMiddleware(MakeRateLimitMiddleware(RateLimitConfig{Limit: 2, Period: time.Minute, Key: RateLimitByHeader("X-Client")}))
+	rt.GET("/", func(ctx *Context) {
+		ctx.WriteString("ok")
+	})
+	request := func(client string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if len(client) > 0 {
+			r.Header.Set("X-Client", client)
+		}
+		rec := httptest.NewRecorder()
+		rt.ServeHTTP(rec, r)
+		return rec
+	}
+
+	cases := []struct {
+		status    int
+		remaining string
+		reset     string
+		retry     string
+	}{
+		{http.StatusOK, "1", "30", ""},
+		{http.StatusOK, "0", "60", ""},
+		{http.StatusTooManyRequests, "0", "60", "30"},
+	}
+	for i, tc := range cases {
+		rec := request("a")
+		headers := rec.Header()
+		if rec.Code != tc.status {
+			t.Errorf("request %d: expected %d, got %d", i, tc.status, rec.Code)
+		}
+		if headers.Get("RateLimit-Policy") != "2;w=60" || headers.Get("RateLimit-Limit") != "2" {
+			t.Errorf("request %d: unexpected policy headers %v", i, headers)
+		}
+		if headers.Get("RateLimit-Remaining") != tc.remaining || headers.Get("RateLimit-Reset") != tc.reset {
+			t.Errorf("request %d: got remaining %q reset %q, want %q %q", i,
+				headers.Get("RateLimit-Remaining"), headers.Get("RateLimit-Reset"), tc.remaining, tc.reset)
+		}
+		if headers.Get("Retry-After") != tc.retry {
+			t.Errorf("request %d: got Retry-After %q, want %q", i, headers.Get("Retry-After"), tc.retry)
+		}
+	}
+
+	if rec := request(""); rec.Code != http.StatusOK || len(rec.Header().Get("RateLimit-Limit")) > 0 {
+		t.Errorf("expected requests without a key to bypass the limiter, got %d %v", rec.Code, rec.Header())
+	}
+}
@@ -0,0 +1,268 @@
+package lib
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+/*
+ResponseRecorder Object
+*/
+type ResponseRecorder struct {
+	writer    http.ResponseWriter
+	status    int
+	size      int64
+	started   time.Time
+	firstByte time.Time
+	hijacked  bool
+	caps      int
+	public    http.ResponseWriter
+}
+
+type fullResponseWriter interface {
+	http.ResponseWriter
+	http.Flusher
+	http.Hijacker
+	http.Pusher
+	io.ReaderFrom
+	Unwrap() http.ResponseWriter
+}
+
+type basicResponseWriter interface {
+	http.ResponseWriter
+	io.ReaderFrom
+	Unwrap() http.ResponseWriter
+}
+
+const (
+	capFlusher = 1 << iota
+	capHijacker
+	capPusher
+)
+
+func responseWriterCaps(w http.ResponseWriter) int {
+	caps := 0
+	if _, ok := w.(http.Flusher); ok {
+		caps |= capFlusher
+	}
+	if _, ok := w.(http.Hijacker); ok {
+		caps |= capHijacker
+	}
+	if _, ok := w.(http.Pusher); ok {
+		caps |= capPusher
+	}
+	return caps
+}
+
+func composeResponseWriter(base fullResponseWriter, caps int) http.ResponseWriter {
+	flusher := caps&capFlusher != 0
+	hijacker := caps&capHijacker != 0
+	pusher := caps&capPusher != 0
+	switch {
+	case flusher && hijacker && pusher:
+		return struct {
+			basicResponseWriter
+			http.Flusher
+			http.Hijacker
+			http.Pusher
+		}{base, base, base, base}
+	case flusher && hijacker:
+		return struct {
+			basicResponseWriter
+			http.Flusher
+			http.Hijacker
+		}{base, base, base}
+	case flusher && pusher:
+		return struct {
+			basicResponseWriter
+			http.Flusher
+			http.Pusher
+		}{base, base, base}
+	case hijacker && pusher:
+		return struct {
+			basicResponseWriter
+			http.Hijacker
+			http.Pusher
+		}{base, base, base}
+	case flusher:
+		return struct {
+			basicResponseWriter
+			http.Flusher
+		}{base, base}
+	case hijacker:
+		return struct {
+			basicResponseWriter
+			http.Hijacker
+		}{base, base}
+	case pusher:
+		return struct {
+			basicResponseWriter
+			http.Pusher
+		}{base, base}
+	}
+	return struct {
+		basicResponseWriter
+	}{base}
+}
+
+/*
+Header Method
+*/
+func (rr *ResponseRecorder) Header() http.Header {
+	return rr.writer.Header()
+}
+
+/*
+WriteHeader Method
+*/
+func (rr *ResponseRecorder) WriteHeader(code int) {
+	if rr.status == 0 {
+		rr.status = code
+		rr.firstByte = time.Now()
+	}
+	rr.writer.WriteHeader(code)
+}
+
+/*
+Write Method
+*/
+func (rr *ResponseRecorder) Write(data []byte) (int, error) {
+	if rr.status == 0 {
+		rr.WriteHeader(http.StatusOK)
+	}
+	n, err := rr.writer.Write(data)
+	rr.size += int64(n)
+	return n, err
+}
+
+/*
+ReadFrom Method
+*/
+func (rr *ResponseRecorder) ReadFrom(reader io.Reader) (int64, error) {
+	if rr.status == 0 {
+		rr.WriteHeader(http.StatusOK)
+	}
+	if readerFrom, ok := rr.writer.(io.ReaderFrom); ok {
+		n, err := readerFrom.ReadFrom(reader)
+		rr.size += n
+		return n, err
+	}
+	return io.Copy(struct{ io.Writer }{rr}, reader)
+}
+
+/*
+Flush Method
+*/
+func (rr *ResponseRecorder) Flush() {
+	if flusher, ok := rr.writer.(http.Flusher); ok {
+		if rr.status == 0 {
+			rr.WriteHeader(http.StatusOK)
+		}
+		flusher.Flush()
+	}
+}
+
+/*
+Hijack Method
+*/
+func (rr *ResponseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := rr.writer.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err == nil {
+		rr.hijacked = true
+	}
+	return conn, rw, err
+}
+
+/*
+Push Method
+*/
+func (rr *ResponseRecorder) Push(target string, opts *http.PushOptions) error {
+	if pusher, ok := rr.writer.(http.Pusher); ok {
+		return pusher.Push(target, opts)
+	}
+	return http.ErrNotSupported
+}
+
+/*
+ResponseWriter Method
+*/
+func (rr *ResponseRecorder) ResponseWriter() http.ResponseWriter {
+	return rr.public
+}
+
+/*
+Unwrap Method
+*/
+func (rr *ResponseRecorder) Unwrap() http.ResponseWriter {
+	return rr.writer
+}
+
+/*
+Status Method
+*/
+func (rr *ResponseRecorder) Status() int {
+	return rr.status
+}
+
+/*
+Size Method
+*/
+func (rr *ResponseRecorder) Size() int64 {
+	return rr.size
+}
+
+/*
+Written Method
+*/
+func (rr *ResponseRecorder) Written() bool {
+	return rr.status != 0
+}
+
+/*
+Hijacked Method
+*/
+func (rr *ResponseRecorder) Hijacked() bool {
+	return rr.hijacked
+}
+
+/*
+Started Method
+*/
+func (rr *ResponseRecorder) Started() time.Time {
+	return rr.started
+}
+
+/*
+TimeToFirstByte Method
+*/
+func (rr *ResponseRecorder) TimeToFirstByte() time.Duration {
+	if rr.firstByte.IsZero() {
+		return 0
+	}
+	return rr.firstByte.Sub(rr.started)
+}
+
+func (rr *ResponseRecorder) reset(w http.ResponseWriter) {
+	caps, public := responseWriterCaps(w), rr.public
+	*rr = ResponseRecorder{writer: w, started: time.Now(), caps: caps, public: public}
+	if rr.public == nil || responseWriterCaps(rr.public) != caps {
+		rr.public = composeResponseWriter(rr, caps)
+	}
+}
+
+/*
+NewResponseRecorder Function
+*/
+func NewResponseRecorder(w http.ResponseWriter) *ResponseRecorder {
+	rr := &ResponseRecorder{}
+	rr.reset(w)
+	return rr
+}
@@ -0,0 +1,36 @@
+package lib
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type plainResponseWriter struct {
+	header http.Header
+}
+
+func (w *plainResponseWriter) Header() http.Header         { return w.header }
+func (w *plainResponseWriter) Write(b []byte) (int, error) { return len(b), nil }
+func (w *plainResponseWriter) WriteHeader(int)             {}
+
+func TestRecorderPreservesInterfaces(t *testing.T) {
+	rw := NewResponseRecorder(&plainResponseWriter{header: http.Header{}}).ResponseWriter()
+	if _, ok := rw.(http.Flusher); ok {
+		t.Error("expected no http.Flusher for a writer without Flush")
+	}
+	if _, ok := rw.(http.Hijacker); ok {
+		t.Error("expected no http.Hijacker for a writer without Hijack")
+	}
+	if _, ok := rw.(http.Pusher); ok {
+		t.Error("expected no http.Pusher for a writer without Push")
+	}
+
+	rw = NewResponseRecorder(httptest.NewRecorder()).ResponseWriter()
+	if _, ok := rw.(http.Flusher); !ok {
+		t.Error("expected http.Flusher to be preserved")
+	}
+	if _, ok := rw.(http.Hijacker); ok {
+		t.Error("expected no http.Hijacker for httptest.ResponseRecorder")
+	}
+}
@@ -4,3 +4,19 @@ package lib
 RequestHandler Object
 */
 type RequestHandler func(*Context)
+
+/*
+RequestErrorHandler Object
+*/
+type RequestErrorHandler func(*Context) error
+
+/*
+Handler Method
+*/
+func (handler RequestErrorHandler) Handler() RequestHandler {
+	return func(ctx *Context) {
+		if err := handler(ctx); err != nil {
+			ctx.Fail(err)
+		}
+	}
+}
@@ -6,3 +6,10 @@ ContextResponder Object
 type ContextResponder interface {
 	HandleError(*Context, int, string)
 }
+
+/*
+ErrorResolver Interface
+*/
+type ErrorResolver interface {
+	ResolveError(error) *HTTPError
+}
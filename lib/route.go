@@ -21,11 +21,13 @@ type Route struct {
 	PatternMixin
 	MatcherMixin
 	MiddlewareMixin
+	TimeoutMixin
 
 	path          string
 	pathMatcher   *PathMatcher
 	methodMatcher *MethodMatcher
 	handler       RequestHandler
+	endpoint      Middleware
 	config        RouteConfig
 }
 
@@ -35,12 +37,18 @@ func (r *Route) init() {
 	r.PatternMixin.initPatterns()
 	r.MatcherMixin.initMatchers()
 	r.MiddlewareMixin.initMiddlewares()
+	r.TimeoutMixin.initTimeout()
 
 	r.params.Copy(ParseParams(r.path))
 	pathMatcher := PathMatch(r.path)
+	pathMatcher.capture = true
 	r.pathMatcher = pathMatcher
 	r.reportToPathMatcher()
 	r.AddMatcher(r.pathMatcher)
+	r.endpoint = func(ctx *Context, next PipelineCallback) {
+		r.Handle(ctx)
+		next()
+	}
 
 	methodMatcher := MethodMatch()
 	r.methodMatcher = methodMatcher
@@ -176,13 +184,28 @@ func (r *Route) PathRegexp() *regexp.Regexp {
 GenerateParams Method
 */
 func (r *Route) GenerateParams(path string) *Params {
-	params := &Params{}
-	params.Copy(*r.Params())
 	reg := r.PathRegexp()
-	if reg != nil && params.Size() > 0 {
-		matches := reg.FindStringSubmatch(path)
+	if reg == nil || r.Params().Size() < 1 {
+		return r.fillParams(nil, nil)
+	}
+	return r.fillParams(reg, reg.FindStringSubmatch(path))
+}
+
+/*
+MatchedParams Method
+*/
+func (r *Route) MatchedParams(ctx *Context) *Params {
+	if ctx.pathMatcher == r.pathMatcher && ctx.pathValues != nil {
+		return r.fillParams(r.PathRegexp(), ctx.pathValues)
+	}
+	return r.GenerateParams(ctx.Path)
+}
+
+func (r *Route) fillParams(reg *regexp.Regexp, matches []string) *Params {
+	params := r.Params().Clone()
+	if reg != nil && matches != nil {
 		for i, name := range reg.SubexpNames() {
-			if i != 0 {
+			if i != 0 && len(name) > 0 {
 				params.Set(name, matches[i])
 			}
 		}
@@ -0,0 +1,39 @@
+package lib
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentParams(t *testing.T) {
+	rt := PlainRouter()
+	rt.GET("/user/:id", func(ctx *Context) {
+		ctx.Write([]byte(ctx.Params.Get("id")))
+	})
+
+	const workers, requests = 32, 100
+	var wg sync.WaitGroup
+	errs := make(chan string, workers*requests)
+	for g := 0; g < workers; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < requests; i++ {
+				id := strconv.Itoa(g*requests + i)
+				w := httptest.NewRecorder()
+				rt.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/user/"+id, nil))
+				if body := w.Body.String(); body != id {
+					errs <- "want " + id + ", got " + body
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
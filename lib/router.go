@@ -18,10 +18,13 @@ type Router struct {
 	HandlerMixin
 	MiddlewareMixin
 	ErrorHandlerMixin
+	ProxyMixin
+	TimeoutMixin
 
 	prefix        string
 	pathMatcher   *PathMatcher
 	methodMatcher *MethodMatcher
+	notFound      Middleware
 }
 
 func (rg *Router) init() {
@@ -32,6 +35,8 @@ func (rg *Router) init() {
 	rg.HandlerMixin.initHandlers()
 	rg.MiddlewareMixin.initMiddlewares()
 	rg.ErrorHandlerMixin.initErrorHandlers()
+	rg.ProxyMixin.initProxies()
+	rg.TimeoutMixin.initTimeout()
 
 	rg.params.Copy(ParseParams(rg.prefix))
 	pathMatcher := PathMatch(rg.prefix)
@@ -42,6 +47,7 @@ func (rg *Router) init() {
 	methodMatcher := MethodMatch()
 	rg.methodMatcher = methodMatcher
 	rg.AddMatcher(methodMatcher)
+	rg.notFound = rg.MakeErrorHandler(http.StatusNotFound, "Page not found")
 }
 
 /*
@@ -246,7 +252,7 @@ ServeFile Method
 */
 func (rg *Router) ServeFile(path string, file string) *Route {
 	route := rg.GET(path, func(ctx *Context) {
-		http.ServeFile(ctx.IOWriter, ctx.Request, file)
+		http.ServeFile(ctx.ResponseWriter(), ctx.Request, file)
 		ctx.MarkFinalized()
 	})
 	route.ClearFormats()
@@ -258,7 +264,7 @@ ServeHandler Method
 */
 func (rg *Router) ServeHandler(handler http.Handler) RequestHandler {
 	return func(ctx *Context) {
-		handler.ServeHTTP(ctx.IOWriter, ctx.Request)
+		handler.ServeHTTP(ctx.ResponseWriter(), ctx.Request)
 		ctx.MarkFinalized()
 	}
 }
@@ -327,15 +333,21 @@ func (rg *Router) Debug() []string {
 WalkRoutes Method
 */
 func (rg *Router) WalkRoutes(cb RouteCallback) bool {
+	return rg.walkRoutes(func(owner *Router, r *Route) bool {
+		return cb(r)
+	})
+}
+
+func (rg *Router) walkRoutes(cb func(*Router, *Route) bool) bool {
 	for _, handler := range *rg.Handlers() {
 		switch hn := handler.(type) {
 		case *Router:
-			res := hn.WalkRoutes(cb)
+			res := hn.walkRoutes(cb)
 			if res == false {
 				return false
 			}
 		case *Route:
-			res := cb(hn)
+			res := cb(rg, hn)
 			if res == false {
 				return false
 			}
@@ -350,7 +362,7 @@ Handle Method
 func (rg *Router) Handle(ctx *Context) {
 	defer func() {
 		if r := recover(); r != nil {
-			ctx.Error(http.StatusInternalServerError, fmt.Sprintf("%v", r))
+			ctx.Recover(r)
 			ctx.Finalize()
 		}
 	}()
@@ -358,7 +370,7 @@ func (rg *Router) Handle(ctx *Context) {
 	var route *Route
 	activeRouter := rg
 	rgSub, route := rg.FindRoute(ctx)
-	pipeline := NewPipeline(ctx)
+	pipeline := acquirePipeline(ctx)
 	if rgSub != nil {
 		activeRouter = rgSub
 		ctx.SetResponder(activeRouter)
@@ -377,29 +389,34 @@ func (rg *Router) Handle(ctx *Context) {
 
 	if route == nil {
 		pipeline.Copy(activeRouter)
-		pipeline.Add(activeRouter.MakeErrorHandler(http.StatusNotFound, "Page not found"))
-		ctx.SetMatched(false)
+		pipeline.Add(activeRouter.notFound)
+		ctx.SetRoute(nil)
 	} else {
 		pipeline.Copy(route)
-		pipeline.Add(activeRouter.wrapRoute(route))
-		ctx.SetMatched(true)
-		ctx.SetParams(route.GenerateParams(ctx.Path))
+		pipeline.Add(route.endpoint)
+		ctx.SetRoute(route)
+		ctx.SetParams(route.MatchedParams(ctx))
 	}
 
-	pipeline.Start(func() {
-		ctx.Start()
-	}, func() {
-		ctx.Finalize()
-	})
+	ctx.Start()
+	if timeout := activeRouter.routeTimeout(route); timeout > 0 {
+		activeRouter.startWithTimeout(ctx, pipeline, timeout)
+		return
+	}
+	pipeline.Start()
+	ctx.Finalize()
+	releasePipeline(pipeline)
 }
 
 /*
 ServerHandler Method
 */
 func (rg *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	ctx := NewContext(w, r)
+	ctx := AcquireContext(w, r)
 	ctx.SetResponder(rg)
+	ctx.SetProxies(rg.TrustedProxies())
 	rg.Handle(ctx)
+	ReleaseContext(ctx)
 }
 
 /*
@@ -420,22 +437,18 @@ func (rg *Router) HandleError(ctx *Context, errorCode int, errorMsg string) {
 	ctx.Status(errorCode)
 	if errorHandler != nil {
 		errorHandler(ctx)
+	} else if responder := rg.GetErrorResponder(); responder != nil {
+		responder.HandleError(ctx, errorCode, errorMsg)
 	} else {
 		ctx.Write([]byte(fmt.Sprintf("Error #%d => %s", errorCode, errorMsg)))
 	}
 }
 
-func (rg *Router) wrapRoute(r *Route) Middleware {
-	return func(ctx *Context, next PipelineCallback) {
-		r.Handle(ctx)
-		next()
-	}
-}
-
 /*
 FindRoute Method
 */
 func (rg *Router) FindRoute(ctx *Context) (*Router, *Route) {
+	checked, matched := false, false
 	for _, handler := range *rg.Handlers() {
 		switch hn := handler.(type) {
 		case *Router:
@@ -444,7 +457,11 @@ func (rg *Router) FindRoute(ctx *Context) (*Router, *Route) {
 				return rgSub, rt
 			}
 		case *Route:
-			if rg.Match(ctx) && hn.Match(ctx) {
+			if !checked {
+				matched = rg.Match(ctx)
+				checked = true
+			}
+			if matched && hn.Match(ctx) {
 				return rg, hn
 			}
 		}
@@ -452,6 +469,51 @@ func (rg *Router) FindRoute(ctx *Context) (*Router, *Route) {
 	return nil, nil
 }
 
+/*
+RouteMatch Object
+*/
+type RouteMatch struct {
+	Router *Router
+	Route  *Route
+	Params *Params
+}
+
+/*
+Lookup Method
+*/
+func (rg *Router) Lookup(method string, rawURL string, headers http.Header) (*RouteMatch, error) {
+	r, err := http.NewRequest(method, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if headers != nil {
+		r.Header = headers.Clone()
+		if host := headers.Get("Host"); len(host) > 0 {
+			r.Host = host
+		}
+	}
+	return rg.LookupRequest(r), nil
+}
+
+/*
+LookupRequest Method
+*/
+func (rg *Router) LookupRequest(r *http.Request) *RouteMatch {
+	ctx := rg.lookupContext(r)
+	rgSub, route := rg.FindRoute(ctx)
+	if route == nil {
+		return nil
+	}
+	return &RouteMatch{Router: rgSub, Route: route, Params: route.MatchedParams(ctx)}
+}
+
+func (rg *Router) lookupContext(r *http.Request) *Context {
+	ctx := NewContext(nil, r)
+	ctx.SetResponder(rg)
+	ctx.SetProxies(rg.TrustedProxies())
+	return ctx
+}
+
 /*
 PlainRouter Function
 */
@@ -481,6 +543,8 @@ func RouterWithPrefix(prefix string, parent *Router) *Router {
 		rg.CopyMatchers(parent.MatcherMixin)
 		rg.CopyMiddlewares(parent.MiddlewareMixin)
 		rg.CopyErrorHandlers(parent.ErrorHandlerMixin)
+		rg.CopyProxies(parent.ProxyMixin)
+		rg.CopyTimeout(parent.TimeoutMixin)
 	}
 	return rg
 }
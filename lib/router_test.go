@@ -0,0 +1,68 @@
+package lib
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func benchmarkRouter() *Router {
+	rt := PlainRouter()
+	rt.GET("/", func(ctx *Context) {
+		ctx.Write([]byte("home"))
+	})
+	rt.GET("/user/:id", func(ctx *Context) {
+		ctx.Write([]byte(ctx.Params.Get("id")))
+	})
+	return rt
+}
+
+func benchmarkServeHTTP(b *testing.B, target string) {
+	rt := benchmarkRouter()
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	w := httptest.NewRecorder()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w.Body.Reset()
+		rt.ServeHTTP(w, r)
+	}
+}
+
+func BenchmarkServeHTTPStatic(b *testing.B) {
+	benchmarkServeHTTP(b, "/")
+}
+
+func BenchmarkServeHTTPParam(b *testing.B) {
+	benchmarkServeHTTP(b, "/user/42")
+}
+
+func TestPathMatchedOnce(t *testing.T) {
+	rt := benchmarkRouter()
+	ctx := NewContext(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/user/42", nil))
+	_, route := rt.FindRoute(ctx)
+	if route == nil {
+		t.Fatal("expected /user/42 to match a route")
+	}
+	if ctx.pathMatcher != route.pathMatcher || ctx.pathValues == nil {
+		t.Fatal("expected the path matcher to capture its submatches")
+	}
+	ctx.Path = "/user/other"
+	if id := route.MatchedParams(ctx).Get("id"); id != "42" {
+		t.Fatalf("expected params from the captured match, got %q", id)
+	}
+}
+
+func BenchmarkServeHTTPParallel(b *testing.B) {
+	rt := benchmarkRouter()
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		r := httptest.NewRequest(http.MethodGet, "/user/42", nil)
+		w := httptest.NewRecorder()
+		for pb.Next() {
+			w.Body.Reset()
+			rt.ServeHTTP(w, r)
+		}
+	})
+}
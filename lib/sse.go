@@ -0,0 +1,182 @@
+package lib
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+ErrEventStreamClosed Variable
+*/
+var ErrEventStreamClosed = errors.New("event stream closed")
+
+/*
+Event Object
+*/
+type Event struct {
+	ID    string
+	Event string
+	Data  string
+	Retry time.Duration
+}
+
+/*
+Encode Method
+*/
+func (ev *Event) Encode() string {
+	var sb strings.Builder
+	if len(ev.ID) > 0 {
+		sb.WriteString("id: " + sseField(ev.ID) + "\n")
+	}
+	if len(ev.Event) > 0 {
+		sb.WriteString("event: " + sseField(ev.Event) + "\n")
+	}
+	if ev.Retry > 0 {
+		sb.WriteString("retry: " + strconv.FormatInt(int64(ev.Retry/time.Millisecond), 10) + "\n")
+	}
+	data := strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(ev.Data)
+	for _, line := range strings.Split(data, "\n") {
+		sb.WriteString("data: " + line + "\n")
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+func sseField(val string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(val)
+}
+
+/*
+EventStream Object
+*/
+type EventStream struct {
+	ctx    *Context
+	mu     sync.Mutex
+	closed bool
+	stop   chan struct{}
+	wg     sync.WaitGroup
+}
+
+/*
+LastEventID Method
+*/
+func (es *EventStream) LastEventID() string {
+	return es.ctx.Request.Header.Get("Last-Event-ID")
+}
+
+/*
+Done Method
+*/
+func (es *EventStream) Done() <-chan struct{} {
+	return es.ctx.Done()
+}
+
+/*
+Closed Method
+*/
+func (es *EventStream) Closed() bool {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	return es.closed || es.ctx.Err() != nil
+}
+
+/*
+Send Method
+*/
+func (es *EventStream) Send(ev Event) error {
+	return es.write(ev.Encode())
+}
+
+/*
+SendData Method
+*/
+func (es *EventStream) SendData(data string) error {
+	return es.Send(Event{Data: data})
+}
+
+/*
+SendEvent Method
+*/
+func (es *EventStream) SendEvent(name string, data string) error {
+	return es.Send(Event{Event: name, Data: data})
+}
+
+/*
+Comment Method
+*/
+func (es *EventStream) Comment(text string) error {
+	return es.write(": " + sseField(text) + "\n\n")
+}
+
+/*
+Heartbeat Method
+*/
+func (es *EventStream) Heartbeat(interval time.Duration) {
+	done := es.ctx.Done()
+	es.wg.Add(1)
+	go func() {
+		defer es.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if es.Comment("heartbeat") != nil {
+					return
+				}
+			case <-es.stop:
+				return
+			case <-done:
+				return
+			}
+		}
+	}()
+}
+
+/*
+Close Method
+*/
+func (es *EventStream) Close() {
+	es.mu.Lock()
+	if !es.closed {
+		es.closed = true
+		close(es.stop)
+	}
+	es.mu.Unlock()
+	es.wg.Wait()
+}
+
+func (es *EventStream) write(payload string) error {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	if es.closed || es.ctx.Err() != nil {
+		return ErrEventStreamClosed
+	}
+	if _, err := es.ctx.Writer.WriteString(payload); err != nil {
+		es.closed = true
+		close(es.stop)
+		return err
+	}
+	es.ctx.Flush()
+	return nil
+}
+
+/*
+SSE Method
+*/
+func (ctx *Context) SSE() *EventStream {
+	ctx.Writer.Header("Content-Type", "text/event-stream")
+	ctx.Writer.Header("Cache-Control", "no-cache")
+	ctx.Writer.Header("Connection", "keep-alive")
+	ctx.Writer.Header("X-Accel-Buffering", "no")
+	ctx.Writer.DelHeader("Content-Length")
+	ctx.Stream()
+	ctx.Flush()
+	ctx.Detach()
+	es := &EventStream{ctx: ctx, stop: make(chan struct{})}
+	ctx.OnFinalize(es.Close)
+	return es
+}
@@ -0,0 +1,222 @@
+package lib
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+/*
+TimeoutMixin Object
+*/
+type TimeoutMixin struct {
+	timeout time.Duration
+}
+
+func (tm *TimeoutMixin) initTimeout() {
+	tm.timeout = 0
+}
+
+/*
+SetTimeout Method
+*/
+func (tm *TimeoutMixin) SetTimeout(timeout time.Duration) *TimeoutMixin {
+	tm.timeout = timeout
+	return tm
+}
+
+/*
+GetTimeout Method
+*/
+func (tm *TimeoutMixin) GetTimeout() time.Duration {
+	return tm.timeout
+}
+
+/*
+CopyTimeout Method
+*/
+func (tm *TimeoutMixin) CopyTimeout(tm2 TimeoutMixin) {
+	tm.timeout = tm2.timeout
+}
+
+/*
+Timeout Method
+*/
+func (r *Route) Timeout(timeout time.Duration) *Route {
+	r.SetTimeout(timeout)
+	return r
+}
+
+/*
+Timeout Method
+*/
+func (rg *Router) Timeout(timeout time.Duration) *Router {
+	rg.SetTimeout(timeout)
+	return rg
+}
+
+func (rg *Router) routeTimeout(route *Route) time.Duration {
+	if route != nil && route.GetTimeout() > 0 {
+		return route.GetTimeout()
+	}
+	return rg.GetTimeout()
+}
+
+func (rg *Router) startWithTimeout(ctx *Context, pipeline *Pipeline, timeout time.Duration) {
+	c, cancel := context.WithTimeout(ctx.Context(), timeout)
+	defer cancel()
+	ctx.WithContext(c)
+
+	guard := &timeoutWriter{writer: ctx.IOWriter, header: http.Header{}}
+	shadow := ctx.shadow(composeResponseWriter(guard, responseWriterCaps(ctx.IOWriter)))
+	pipeline.ctx = shadow
+	done := make(chan interface{}, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- NewPanicError(r, debug.Stack())
+				return
+			}
+			done <- nil
+		}()
+		pipeline.Start()
+	}()
+
+	select {
+	case p := <-done:
+		if p != nil {
+			panic(p)
+		}
+		ctx.merge(shadow)
+		ctx.Finalize()
+		releasePipeline(pipeline)
+	case <-c.Done():
+		if guard.timeout() || ctx.recorder.Hijacked() {
+			ctx.MarkFinalized()
+		} else {
+			ctx.Error(http.StatusServiceUnavailable, "Request timeout")
+		}
+		ctx.Finalize()
+	}
+}
+
+func (ctx *Context) shadow(iow http.ResponseWriter) *Context {
+	shadow := *ctx
+	shadow.Writer = NewWriter()
+	shadow.recorder = NewResponseRecorder(iow)
+	shadow.IOWriter = shadow.recorder.ResponseWriter()
+	shadow.buffered = nil
+	shadow.Errors = &Errors{}
+	*shadow.Errors = append(*shadow.Errors, *ctx.Errors...)
+	shadow.Params = ctx.Params.Clone()
+	shadow.values = &ObjectMap{}
+	shadow.values.Copy(*ctx.values)
+	shadow.finalizers = append([]PipelineCallback(nil), ctx.finalizers...)
+	return &shadow
+}
+
+func (ctx *Context) merge(shadow *Context) {
+	recorder, iow := ctx.recorder, ctx.IOWriter
+	*ctx = *shadow
+	ctx.recorder = recorder
+	ctx.IOWriter = iow
+	ctx.buffered = nil
+}
+
+type timeoutWriter struct {
+	mu          sync.Mutex
+	writer      http.ResponseWriter
+	header      http.Header
+	wroteHeader bool
+	timedOut    bool
+}
+
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+func (tw *timeoutWriter) WriteHeader(code int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if !tw.timedOut && !tw.wroteHeader {
+		tw.writeHeader(code)
+	}
+}
+
+func (tw *timeoutWriter) writeHeader(code int) {
+	tw.wroteHeader = true
+	dst := tw.writer.Header()
+	for key, vals := range tw.header {
+		dst[key] = vals
+	}
+	tw.writer.WriteHeader(code)
+}
+
+func (tw *timeoutWriter) Write(data []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	if !tw.wroteHeader {
+		tw.writeHeader(http.StatusOK)
+	}
+	return tw.writer.Write(data)
+}
+
+func (tw *timeoutWriter) Flush() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return
+	}
+	if flusher, ok := tw.writer.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (tw *timeoutWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return nil, nil, http.ErrHandlerTimeout
+	}
+	hijacker, ok := tw.writer.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	return hijacker.Hijack()
+}
+
+func (tw *timeoutWriter) Push(target string, opts *http.PushOptions) error {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return http.ErrHandlerTimeout
+	}
+	if pusher, ok := tw.writer.(http.Pusher); ok {
+		return pusher.Push(target, opts)
+	}
+	return http.ErrNotSupported
+}
+
+func (tw *timeoutWriter) ReadFrom(reader io.Reader) (int64, error) {
+	return io.Copy(struct{ io.Writer }{tw}, reader)
+}
+
+func (tw *timeoutWriter) Unwrap() http.ResponseWriter {
+	return tw.writer
+}
+
+func (tw *timeoutWriter) timeout() bool {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	tw.timedOut = true
+	return tw.wroteHeader
+}
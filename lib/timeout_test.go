@@ -0,0 +1,92 @@
+package lib
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTimeoutBufferedHandler(t *testing.T) {
+	done := make(chan struct{})
+	rt := PlainRouter()
+	rt.SetErrorHandler(http.StatusServiceUnavailable, func(ctx *Context) {
+		ctx.WriteString("try again later")
+	})
+	rt.GET("/slow", func(ctx *Context) {
+		defer close(done)
+		ctx.Writer.Header("X-Partial", "1")
+		ctx.WriteString("partial")
+		time.Sleep(50 * time.Millisecond)
+		ctx.WriteString("late")
+	}).Timeout(10 * time.Millisecond)
+
+	rec := httptest.NewRecorder()
+	rt.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/slow", nil))
+	<-done
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503, got %d", rec.Code)
+	}
+	if rec.Body.String() != "try again later" {
+		t.Errorf("expected only the error handler output, got %q", rec.Body.String())
+	}
+	if len(rec.Header().Get("X-Partial")) > 0 {
+		t.Error("expected partial headers to be discarded")
+	}
+}
+
+func TestTimeoutStreamingHandler(t *testing.T) {
+	done := make(chan error, 1)
+	rt := PlainRouter()
+	rt.GET("/stream", func(ctx *Context) {
+		ctx.Stream()
+		ctx.WriteString("first")
+		ctx.Flush()
+		time.Sleep(50 * time.Millisecond)
+		_, err := ctx.IOWriter.Write([]byte("late"))
+		ctx.WriteString("late")
+		ctx.Flush()
+		done <- err
+	}).Timeout(10 * time.Millisecond)
+
+	rec := httptest.NewRecorder()
+	rt.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stream", nil))
+	if err := <-done; !errors.Is(err, http.ErrHandlerTimeout) {
+		t.Errorf("expected ErrHandlerTimeout for late writes, got %v", err)
+	}
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected committed 200 to stand, got %d", rec.Code)
+	}
+	if rec.Body.String() != "first" {
+		t.Errorf("expected late writes to be dropped, got %q", rec.Body.String())
+	}
+}
+
+func TestTimeoutPanic(t *testing.T) {
+	boom := errors.New("boom")
+	var recovered *PanicError
+	rt := PlainRouter()
+	rt.SetPanicHandler(func(ctx *Context, pe *PanicError) {
+		recovered = pe
+	})
+	rt.GET("/panic", func(ctx *Context) {
+		ctx.WriteString("partial")
+		panic(boom)
+	}).Timeout(time.Second)
+
+	rec := httptest.NewRecorder()
+	rt.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500, got %d", rec.Code)
+	}
+	if recovered == nil || !errors.Is(recovered, boom) || len(recovered.Stack) < 1 {
+		t.Errorf("expected panic handler to receive the panic with a stack, got %v", recovered)
+	}
+	if body := rec.Body.String(); len(body) < 1 || body == "partial" {
+		t.Errorf("expected error output instead of partial body, got %q", body)
+	}
+}
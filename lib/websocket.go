@@ -0,0 +1,449 @@
+package lib
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+/*
+WebSocket Message Types
+*/
+const (
+	WebSocketContinuation = 0
+	WebSocketText         = 1
+	WebSocketBinary       = 2
+	WebSocketClose        = 8
+	WebSocketPing         = 9
+	WebSocketPong         = 10
+)
+
+/*
+WebSocket Close Codes
+*/
+const (
+	CloseNormal          = 1000
+	CloseGoingAway       = 1001
+	CloseProtocolError   = 1002
+	CloseUnsupportedData = 1003
+	CloseNoStatus        = 1005
+	CloseAbnormal        = 1006
+	CloseInvalidPayload  = 1007
+	ClosePolicyViolation = 1008
+	CloseMessageTooBig   = 1009
+	CloseInternalError   = 1011
+)
+
+/*
+DefaultWebSocketReadLimit Constant
+*/
+const DefaultWebSocketReadLimit = 32 << 20
+
+const webSocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+/*
+ErrWebSocketClosed Variable
+*/
+var ErrWebSocketClosed = errors.New("websocket: connection closed")
+
+/*
+WebSocketHandler Object
+*/
+type WebSocketHandler func(*Context, *WebSocket)
+
+/*
+WebSocketCloseError Object
+*/
+type WebSocketCloseError struct {
+	Code   int
+	Reason string
+}
+
+/*
+Error Method
+*/
+func (ce *WebSocketCloseError) Error() string {
+	return fmt.Sprintf("websocket: close %d %s", ce.Code, ce.Reason)
+}
+
+/*
+WebSocketHandshakeError Object
+*/
+type WebSocketHandshakeError struct {
+	Status  int
+	Message string
+}
+
+/*
+Error Method
+*/
+func (he *WebSocketHandshakeError) Error() string {
+	return "websocket: " + he.Message
+}
+
+/*
+WebSocket Object
+*/
+type WebSocket struct {
+	conn        net.Conn
+	reader      *bufio.Reader
+	writeMu     sync.Mutex
+	readLimit   int64
+	closeSent   bool
+	subprotocol string
+	pongHandler func([]byte)
+}
+
+/*
+Subprotocol Method
+*/
+func (ws *WebSocket) Subprotocol() string {
+	return ws.subprotocol
+}
+
+/*
+RemoteAddr Method
+*/
+func (ws *WebSocket) RemoteAddr() net.Addr {
+	return ws.conn.RemoteAddr()
+}
+
+/*
+SetReadLimit Method
+*/
+func (ws *WebSocket) SetReadLimit(limit int64) {
+	ws.readLimit = limit
+}
+
+/*
+SetReadDeadline Method
+*/
+func (ws *WebSocket) SetReadDeadline(t time.Time) error {
+	return ws.conn.SetReadDeadline(t)
+}
+
+/*
+SetWriteDeadline Method
+*/
+func (ws *WebSocket) SetWriteDeadline(t time.Time) error {
+	return ws.conn.SetWriteDeadline(t)
+}
+
+/*
+OnPong Method
+*/
+func (ws *WebSocket) OnPong(handler func([]byte)) {
+	ws.pongHandler = handler
+}
+
+/*
+ReadMessage Method
+*/
+func (ws *WebSocket) ReadMessage() (int, []byte, error) {
+	messageType := 0
+	message := []byte{}
+	for {
+		fin, opcode, payload, err := ws.readFrame(int64(len(message)))
+		if err != nil {
+			return 0, nil, err
+		}
+		switch opcode {
+		case WebSocketPing:
+			if err := ws.Pong(payload); err != nil {
+				return 0, nil, err
+			}
+		case WebSocketPong:
+			if ws.pongHandler != nil {
+				ws.pongHandler(payload)
+			}
+		case WebSocketClose:
+			if len(payload) == 1 {
+				return 0, nil, ws.fail(CloseProtocolError, "invalid close payload")
+			}
+			closeErr := &WebSocketCloseError{Code: CloseNoStatus}
+			if len(payload) >= 2 {
+				closeErr.Code = int(binary.BigEndian.Uint16(payload))
+				closeErr.Reason = string(payload[2:])
+			}
+			if closeErr.Code == CloseNoStatus {
+				ws.writeClose(nil)
+			} else {
+				ws.writeClose(payload[:2])
+			}
+			ws.conn.Close()
+			return 0, nil, closeErr
+		case WebSocketText, WebSocketBinary:
+			if messageType != 0 {
+				return 0, nil, ws.fail(CloseProtocolError, "unexpected data frame inside fragmented message")
+			}
+			messageType = opcode
+			message = append(message, payload...)
+		case WebSocketContinuation:
+			if messageType == 0 {
+				return 0, nil, ws.fail(CloseProtocolError, "unexpected continuation frame")
+			}
+			message = append(message, payload...)
+		default:
+			return 0, nil, ws.fail(CloseProtocolError, fmt.Sprintf("unknown opcode %d", opcode))
+		}
+		if fin && messageType != 0 && opcode < WebSocketClose {
+			if messageType == WebSocketText && !utf8.Valid(message) {
+				return 0, nil, ws.fail(CloseInvalidPayload, "invalid utf-8 in text message")
+			}
+			return messageType, message, nil
+		}
+	}
+}
+
+/*
+ReadText Method
+*/
+func (ws *WebSocket) ReadText() (string, error) {
+	for {
+		messageType, data, err := ws.ReadMessage()
+		if err != nil {
+			return "", err
+		}
+		if messageType == WebSocketText {
+			return string(data), nil
+		}
+	}
+}
+
+/*
+WriteMessage Method
+*/
+func (ws *WebSocket) WriteMessage(messageType int, data []byte) error {
+	if messageType != WebSocketText && messageType != WebSocketBinary {
+		return fmt.Errorf("websocket: invalid message type %d", messageType)
+	}
+	return ws.writeFrame(messageType, data)
+}
+
+/*
+WriteText Method
+*/
+func (ws *WebSocket) WriteText(text string) error {
+	return ws.writeFrame(WebSocketText, []byte(text))
+}
+
+/*
+WriteBinary Method
+*/
+func (ws *WebSocket) WriteBinary(data []byte) error {
+	return ws.writeFrame(WebSocketBinary, data)
+}
+
+/*
+Ping Method
+*/
+func (ws *WebSocket) Ping(data []byte) error {
+	if len(data) > 125 {
+		return errors.New("websocket: control frame payload too large")
+	}
+	return ws.writeFrame(WebSocketPing, data)
+}
+
+/*
+Pong Method
+*/
+func (ws *WebSocket) Pong(data []byte) error {
+	if len(data) > 125 {
+		return errors.New("websocket: control frame payload too large")
+	}
+	return ws.writeFrame(WebSocketPong, data)
+}
+
+/*
+Close Method
+*/
+func (ws *WebSocket) Close(code int, reason string) error {
+	if len(reason) > 123 {
+		reason = reason[:123]
+	}
+	payload := make([]byte, 2, 2+len(reason))
+	binary.BigEndian.PutUint16(payload, uint16(code))
+	payload = append(payload, reason...)
+	err := ws.writeClose(payload)
+	ws.conn.Close()
+	return err
+}
+
+func (ws *WebSocket) fail(code int, reason string) error {
+	ws.Close(code, reason)
+	return &WebSocketCloseError{Code: code, Reason: reason}
+}
+
+func (ws *WebSocket) writeClose(payload []byte) error {
+	ws.writeMu.Lock()
+	defer ws.writeMu.Unlock()
+	if ws.closeSent {
+		return nil
+	}
+	ws.closeSent = true
+	return ws.writeFrameLocked(WebSocketClose, payload)
+}
+
+func (ws *WebSocket) writeFrame(opcode int, data []byte) error {
+	ws.writeMu.Lock()
+	defer ws.writeMu.Unlock()
+	if ws.closeSent {
+		return ErrWebSocketClosed
+	}
+	return ws.writeFrameLocked(opcode, data)
+}
+
+func (ws *WebSocket) writeFrameLocked(opcode int, data []byte) error {
+	header := make([]byte, 2, 10)
+	header[0] = 0x80 | byte(opcode)
+	length := len(data)
+	switch {
+	case length <= 125:
+		header[1] = byte(length)
+	case length <= 0xFFFF:
+		header[1] = 126
+		header = append(header, 0, 0)
+		binary.BigEndian.PutUint16(header[2:], uint16(length))
+	default:
+		header[1] = 127
+		header = append(header, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(header[2:], uint64(length))
+	}
+	buffers := net.Buffers{header, data}
+	_, err := buffers.WriteTo(ws.conn)
+	return err
+}
+
+func (ws *WebSocket) readFrame(received int64) (bool, int, []byte, error) {
+	head := make([]byte, 2)
+	if _, err := io.ReadFull(ws.reader, head); err != nil {
+		return false, 0, nil, err
+	}
+	fin := head[0]&0x80 != 0
+	opcode := int(head[0] & 0x0F)
+	if head[0]&0x70 != 0 {
+		return false, 0, nil, ws.fail(CloseProtocolError, "reserved bits set")
+	}
+	if head[1]&0x80 == 0 {
+		return false, 0, nil, ws.fail(CloseProtocolError, "client frames must be masked")
+	}
+	length := int64(head[1] & 0x7F)
+	switch length {
+	case 126:
+		ext := make([]byte, 2)
+		if _, err := io.ReadFull(ws.reader, ext); err != nil {
+			return false, 0, nil, err
+		}
+		length = int64(binary.BigEndian.Uint16(ext))
+	case 127:
+		ext := make([]byte, 8)
+		if _, err := io.ReadFull(ws.reader, ext); err != nil {
+			return false, 0, nil, err
+		}
+		length = int64(binary.BigEndian.Uint64(ext))
+		if length < 0 {
+			return false, 0, nil, ws.fail(CloseProtocolError, "invalid frame length")
+		}
+	}
+	if opcode >= WebSocketClose && (!fin || length > 125) {
+		return false, 0, nil, ws.fail(CloseProtocolError, "invalid control frame")
+	}
+	if opcode < WebSocketClose && ws.readLimit > 0 && received+length > ws.readLimit {
+		return false, 0, nil, ws.fail(CloseMessageTooBig, "message too big")
+	}
+	mask := make([]byte, 4)
+	if _, err := io.ReadFull(ws.reader, mask); err != nil {
+		return false, 0, nil, err
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(ws.reader, payload); err != nil {
+		return false, 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return fin, opcode, payload, nil
+}
+
+/*
+UpgradeWebSocket Method
+*/
+func (ctx *Context) UpgradeWebSocket(protocols ...string) (*WebSocket, error) {
+	r := ctx.Request
+	if r.Method != http.MethodGet {
+		return nil, &WebSocketHandshakeError{Status: http.StatusMethodNotAllowed, Message: "handshake requires GET"}
+	}
+	if !HeaderHasToken(r.Header, "Connection", "upgrade") || !HeaderHasToken(r.Header, "Upgrade", "websocket") {
+		return nil, &WebSocketHandshakeError{Status: http.StatusBadRequest, Message: "missing upgrade headers"}
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		ctx.Writer.Header("Sec-WebSocket-Version", "13")
+		return nil, &WebSocketHandshakeError{Status: http.StatusUpgradeRequired, Message: "unsupported websocket version"}
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if decoded, err := base64.StdEncoding.DecodeString(key); err != nil || len(decoded) != 16 {
+		return nil, &WebSocketHandshakeError{Status: http.StatusBadRequest, Message: "invalid websocket key"}
+	}
+	subprotocol := ""
+	for _, offered := range strings.Split(r.Header.Get("Sec-WebSocket-Protocol"), ",") {
+		offered = strings.TrimSpace(offered)
+		if len(offered) > 0 && InStringSlice(protocols, offered) {
+			subprotocol = offered
+			break
+		}
+	}
+
+	conn, rw, err := ctx.recorder.Hijack()
+	if err != nil {
+		return nil, &WebSocketHandshakeError{Status: http.StatusInternalServerError, Message: err.Error()}
+	}
+	digest := sha1.Sum([]byte(key + webSocketGUID))
+	headers := ctx.Writer.Headers.Clone()
+	headers.Set("Upgrade", "websocket")
+	headers.Set("Connection", "Upgrade")
+	headers.Set("Sec-WebSocket-Accept", base64.StdEncoding.EncodeToString(digest[:]))
+	if len(subprotocol) > 0 {
+		headers.Set("Sec-WebSocket-Protocol", subprotocol)
+	}
+	headers.Del("Content-Length")
+	rw.WriteString("HTTP/1.1 101 Switching Protocols\r\n")
+	headers.Write(rw)
+	rw.WriteString("\r\n")
+	if err := rw.Flush(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	ctx.recorder.status = http.StatusSwitchingProtocols
+	ctx.MarkFinalized()
+	return &WebSocket{conn: conn, reader: rw.Reader, readLimit: DefaultWebSocketReadLimit, subprotocol: subprotocol}, nil
+}
+
+/*
+WebSocket Method
+*/
+func (rg *Router) WebSocket(path string, handler WebSocketHandler, protocols ...string) *Route {
+	route := rg.GET(path, func(ctx *Context) {
+		ws, err := ctx.UpgradeWebSocket(protocols...)
+		if err != nil {
+			if hsErr, ok := err.(*WebSocketHandshakeError); ok {
+				ctx.Error(hsErr.Status, hsErr.Message)
+			}
+			return
+		}
+		defer ws.Close(CloseNormal, "")
+		handler(ctx, ws)
+	})
+	route.SetMethod(http.MethodGet)
+	route.ClearFormats()
+	return route
+}
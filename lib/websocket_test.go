@@ -0,0 +1,155 @@
+package lib
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type wsFrame struct {
+	opcode  int
+	payload []byte
+}
+
+func clientFrame(fin bool, opcode int, payload []byte, masked bool) []byte {
+	head := byte(opcode)
+	if fin {
+		head |= 0x80
+	}
+	frame := []byte{head, byte(len(payload))}
+	if !masked {
+		return append(frame, payload...)
+	}
+	mask := []byte{0x12, 0x34, 0x56, 0x78}
+	frame[1] |= 0x80
+	frame = append(frame, mask...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	return frame
+}
+
+func wsPipe(frames ...[]byte) (*WebSocket, <-chan wsFrame) {
+	server, client := net.Pipe()
+	ws := &WebSocket{conn: server, reader: bufio.NewReader(server), readLimit: DefaultWebSocketReadLimit}
+	received := make(chan wsFrame, 8)
+	go func() {
+		for _, frame := range frames {
+			if _, err := client.Write(frame); err != nil {
+				return
+			}
+		}
+	}()
+	go func() {
+		defer close(received)
+		reader := bufio.NewReader(client)
+		for {
+			head := make([]byte, 2)
+			if _, err := io.ReadFull(reader, head); err != nil {
+				return
+			}
+			payload := make([]byte, head[1]&0x7F)
+			if _, err := io.ReadFull(reader, payload); err != nil {
+				return
+			}
+			received <- wsFrame{int(head[0] & 0x0F), payload}
+		}
+	}()
+	return ws, received
+}
+
+func expectCloseCode(t *testing.T, err error, received <-chan wsFrame, code int) {
+	t.Helper()
+	var closeErr *WebSocketCloseError
+	if !errors.As(err, &closeErr) || closeErr.Code != code {
+		t.Fatalf("expected close error %d, got %v", code, err)
+	}
+	frame := <-received
+	if frame.opcode != WebSocketClose || len(frame.payload) < 2 || int(binary.BigEndian.Uint16(frame.payload)) != code {
+		t.Errorf("expected close frame with %d, got %v", code, frame)
+	}
+}
+
+func TestWebSocketHandshake(t *testing.T) {
+	rt := PlainRouter()
+	rt.WebSocket("/ws", func(ctx *Context, ws *WebSocket) {}, "chat")
+	server := httptest.NewServer(rt)
+	defer server.Close()
+
+	conn, err := net.Dial("tcp", server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	io.WriteString(conn, "GET /ws HTTP/1.1\r\nHost: example.com\r\nConnection: Upgrade\r\nUpgrade: websocket\r\n"+
+		"Sec-WebSocket-Version: 13\r\nSec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Protocol: other, chat\r\n\r\n")
+	res, err := http.ReadResponse(bufio.NewReader(conn), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("expected 101, got %d", res.StatusCode)
+	}
+	if accept := res.Header.Get("Sec-WebSocket-Accept"); accept != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Errorf("unexpected accept key %q", accept)
+	}
+	if proto := res.Header.Get("Sec-WebSocket-Protocol"); proto != "chat" {
+		t.Errorf("expected chat subprotocol, got %q", proto)
+	}
+}
+
+func TestWebSocketMaskedFrame(t *testing.T) {
+	ws, _ := wsPipe(clientFrame(true, WebSocketText, []byte("hello"), true))
+	messageType, message, err := ws.ReadMessage()
+	if err != nil || messageType != WebSocketText || string(message) != "hello" {
+		t.Errorf("expected text hello, got %d %q %v", messageType, message, err)
+	}
+}
+
+func TestWebSocketUnmaskedFrame(t *testing.T) {
+	ws, received := wsPipe(clientFrame(true, WebSocketText, []byte("hello"), false))
+	_, _, err := ws.ReadMessage()
+	expectCloseCode(t, err, received, CloseProtocolError)
+}
+
+func TestWebSocketFragmentedWithPing(t *testing.T) {
+	ws, received := wsPipe(
+		clientFrame(false, WebSocketText, []byte("Hel"), true),
+		clientFrame(true, WebSocketPing, []byte("p"), true),
+		clientFrame(true, WebSocketContinuation, []byte("lo"), true),
+	)
+	messageType, message, err := ws.ReadMessage()
+	if err != nil || messageType != WebSocketText || string(message) != "Hello" {
+		t.Errorf("expected text Hello, got %d %q %v", messageType, message, err)
+	}
+	if frame := <-received; frame.opcode != WebSocketPong || string(frame.payload) != "p" {
+		t.Errorf("expected pong echoing ping payload, got %v", frame)
+	}
+}
+
+func TestWebSocketReadLimit(t *testing.T) {
+	ws, received := wsPipe(
+		clientFrame(false, WebSocketBinary, []byte("abc"), true),
+		clientFrame(true, WebSocketContinuation, []byte("def"), true),
+	)
+	ws.SetReadLimit(4)
+	_, _, err := ws.ReadMessage()
+	expectCloseCode(t, err, received, CloseMessageTooBig)
+}
+
+func TestWebSocketInvalidUTF8(t *testing.T) {
+	ws, received := wsPipe(clientFrame(true, WebSocketText, []byte{0xff, 0xfe}, true))
+	_, _, err := ws.ReadMessage()
+	expectCloseCode(t, err, received, CloseInvalidPayload)
+}
+
+func TestWebSocketShortClosePayload(t *testing.T) {
+	ws, received := wsPipe(clientFrame(true, WebSocketClose, []byte{0x03}, true))
+	_, _, err := ws.ReadMessage()
+	expectCloseCode(t, err, received, CloseProtocolError)
+}
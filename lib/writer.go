@@ -2,16 +2,38 @@ package lib
 
 import (
 	"bytes"
+	"errors"
+	"io"
 	"net/http"
+	"strconv"
 )
 
+/*
+ErrWriterClosed Variable
+*/
+var ErrWriterClosed = errors.New("writer already pushed to the client")
+
+/*
+StreamFilter Object
+*/
+type StreamFilter func(*Writer, io.Writer) io.Writer
+
 /*
 Writer Object
 */
 type Writer struct {
 	buffer     bytes.Buffer
 	StatusCode int
-	Headers    *ObjectMap
+	Headers    http.Header
+	target     http.ResponseWriter
+	sink       io.Writer
+	layers     []io.Writer
+	filters    []StreamFilter
+	streaming  bool
+	committed  bool
+	closed     bool
+	head       bool
+	streamed   int64
 }
 
 func (w Writer) init() {
@@ -21,15 +43,134 @@ func (w Writer) init() {
 /*
 Write Method
 */
-func (w *Writer) Write(bytes []byte) {
-	w.buffer.Write(bytes)
+func (w *Writer) Write(bytes []byte) (int, error) {
+	if w.closed {
+		return 0, ErrWriterClosed
+	}
+	if w.streaming {
+		w.commit()
+		n, err := w.sink.Write(bytes)
+		w.streamed += int64(n)
+		return n, err
+	}
+	return w.buffer.Write(bytes)
 }
 
 /*
 WriteString Method
 */
-func (w *Writer) WriteString(str string) {
-	w.buffer.WriteString(str)
+func (w *Writer) WriteString(str string) (int, error) {
+	if w.closed {
+		return 0, ErrWriterClosed
+	}
+	if w.streaming {
+		return w.Write([]byte(str))
+	}
+	return w.buffer.WriteString(str)
+}
+
+/*
+Stream Method
+*/
+func (w *Writer) Stream(iow http.ResponseWriter) {
+	w.target = iow
+	w.streaming = true
+}
+
+/*
+AddStreamFilter Method
+*/
+func (w *Writer) AddStreamFilter(filter StreamFilter) {
+	w.filters = append(w.filters, filter)
+}
+
+/*
+Streaming Method
+*/
+func (w *Writer) Streaming() bool {
+	return w.streaming
+}
+
+/*
+Committed Method
+*/
+func (w *Writer) Committed() bool {
+	return w.committed
+}
+
+/*
+Size Method
+*/
+func (w *Writer) Size() int64 {
+	return w.streamed + int64(w.buffer.Len())
+}
+
+/*
+FlushStream Method
+*/
+func (w *Writer) FlushStream() {
+	if !w.streaming {
+		return
+	}
+	w.commit()
+	for i := len(w.layers) - 1; i >= 0; i-- {
+		if flusher, ok := w.layers[i].(interface{ Flush() error }); ok {
+			flusher.Flush()
+		}
+	}
+	if flusher, ok := w.target.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *Writer) commit() {
+	if w.committed {
+		return
+	}
+	w.committed = true
+	w.sink = w.target
+	for _, filter := range w.filters {
+		if layer := filter(w, w.sink); layer != w.sink {
+			w.layers = append(w.layers, layer)
+			w.sink = layer
+		}
+	}
+	w.writeHeaderTo(w.target)
+	if w.buffer.Len() > 0 {
+		n, _ := w.buffer.WriteTo(w.sink)
+		w.streamed += n
+	}
+}
+
+func (w *Writer) closeStream() {
+	w.commit()
+	for i := len(w.layers) - 1; i >= 0; i-- {
+		if closer, ok := w.layers[i].(io.Closer); ok {
+			closer.Close()
+		}
+	}
+	if flusher, ok := w.target.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *Writer) writeHeaderTo(iow http.ResponseWriter) {
+	headers := iow.Header()
+	for hKey, hVals := range w.Headers {
+		headers[hKey] = append([]string(nil), hVals...)
+	}
+	iow.WriteHeader(w.status())
+}
+
+func (w *Writer) status() int {
+	if w.StatusCode > 0 {
+		return w.StatusCode
+	}
+	return http.StatusOK
+}
+
+func bodyAllowed(status int) bool {
+	return status >= 200 && status != http.StatusNoContent && status != http.StatusNotModified
 }
 
 /*
@@ -53,11 +194,39 @@ func (w *Writer) Header(key string, val string) {
 	w.Headers.Set(key, val)
 }
 
+/*
+SetHeader Method
+*/
+func (w *Writer) SetHeader(key string, val string) {
+	w.Headers.Set(key, val)
+}
+
+/*
+AddHeader Method
+*/
+func (w *Writer) AddHeader(key string, val string) {
+	w.Headers.Add(key, val)
+}
+
+/*
+DelHeader Method
+*/
+func (w *Writer) DelHeader(key string) {
+	w.Headers.Del(key)
+}
+
 /*
 GetHeader Method
 */
 func (w *Writer) GetHeader(key string) string {
-	return w.Headers.GetString(key)
+	return w.Headers.Get(key)
+}
+
+/*
+HeaderValues Method
+*/
+func (w *Writer) HeaderValues(key string) []string {
+	return w.Headers.Values(key)
 }
 
 /*
@@ -72,23 +241,56 @@ Flush Method
 */
 func (w *Writer) Flush() {
 	w.ClearBuffer()
-	w.Headers.Clear()
+	for key := range w.Headers {
+		delete(w.Headers, key)
+	}
 }
 
 /*
 PushTo Method
 */
 func (w *Writer) PushTo(iow http.ResponseWriter) {
-	if w.StatusCode > 0 {
-		iow.WriteHeader(w.StatusCode)
-	} else {
-		iow.WriteHeader(http.StatusOK)
+	if w.closed {
+		return
 	}
-	w.buffer.WriteTo(iow)
-	headers := w.Headers.StringMap()
-	for hKey, hVal := range headers {
-		iow.Header().Set(hKey, hVal)
+	w.closed = true
+	if w.streaming {
+		w.closeStream()
+		return
+	}
+	if w.head {
+		w.writeHeaderTo(iow)
+		return
+	}
+	if bodyAllowed(w.status()) && len(w.Headers.Get("Content-Length")) < 1 {
+		w.Headers.Set("Content-Length", strconv.Itoa(w.buffer.Len()))
 	}
+	w.writeHeaderTo(iow)
+	w.buffer.WriteTo(iow)
+}
+
+/*
+Reset Method
+*/
+func (w *Writer) Reset() {
+	w.Flush()
+	w.StatusCode = 0
+	w.target = nil
+	w.sink = nil
+	w.layers = nil
+	w.filters = nil
+	w.streaming = false
+	w.committed = false
+	w.closed = false
+	w.head = false
+	w.streamed = 0
+}
+
+/*
+Closed Method
+*/
+func (w *Writer) Closed() bool {
+	return w.closed
 }
 
 /*
@@ -102,7 +304,7 @@ func (w *Writer) Bytes() []byte {
 NewWriter Function
 */
 func NewWriter() *Writer {
-	writer := &Writer{Headers: &ObjectMap{}}
+	writer := &Writer{Headers: http.Header{}}
 	writer.init()
 	return writer
 }
@@ -0,0 +1,52 @@
+package lib
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type headerSnapshotWriter struct {
+	*httptest.ResponseRecorder
+	sent http.Header
+}
+
+func (w *headerSnapshotWriter) WriteHeader(code int) {
+	w.sent = w.Header().Clone()
+	w.ResponseRecorder.WriteHeader(code)
+}
+
+func TestPushToSendsHeadersBeforeStatus(t *testing.T) {
+	iow := &headerSnapshotWriter{ResponseRecorder: httptest.NewRecorder()}
+	w := NewWriter()
+	w.Status(http.StatusCreated)
+	w.SetHeader("Content-Type", "text/plain")
+	w.AddHeader("Set-Cookie", "a=1")
+	w.AddHeader("Set-Cookie", "b=2")
+	w.WriteString("created")
+	w.PushTo(iow)
+
+	if iow.Code != http.StatusCreated {
+		t.Errorf("expected 201, got %d", iow.Code)
+	}
+	if iow.sent.Get("Content-Type") != "text/plain" || iow.sent.Get("Content-Length") != "7" {
+		t.Errorf("expected headers before WriteHeader, got %v", iow.sent)
+	}
+	if cookies := iow.sent.Values("Set-Cookie"); len(cookies) != 2 || cookies[0] != "a=1" || cookies[1] != "b=2" {
+		t.Errorf("expected both Set-Cookie values, got %v", cookies)
+	}
+}
+
+func TestHeadOmitsContentLength(t *testing.T) {
+	rec := httptest.NewRecorder()
+	ctx := NewContext(rec, httptest.NewRequest(http.MethodHead, "/", nil))
+	ctx.Writer.WriteString("ignored")
+	ctx.Finalize()
+
+	if _, ok := rec.Header()["Content-Length"]; ok {
+		t.Errorf("expected no Content-Length for HEAD, got %q", rec.Header().Get("Content-Length"))
+	}
+	if rec.Body.Len() > 0 {
+		t.Errorf("expected no body for HEAD, got %q", rec.Body.String())
+	}
+}